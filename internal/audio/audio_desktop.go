@@ -11,6 +11,9 @@ import (
 	"github.com/faiface/beep/speaker"
 )
 
+// SampleRate is the rate the speaker runs at
+const SampleRate = 44100
+
 var (
 	speakerInitialized bool
 	speakerMutex       sync.Mutex
@@ -25,7 +28,7 @@ func InitAudio() error {
 		return nil
 	}
 
-	sr := beep.SampleRate(44100)
+	sr := beep.SampleRate(SampleRate)
 	err := speaker.Init(sr, sr.N(time.Second/10))
 	if err != nil {
 		return err
@@ -45,7 +48,7 @@ func PlayFrequency(frequency float64, duration time.Duration) error {
 	// Stop any currently playing sounds to prevent artifacts
 	speaker.Clear()
 
-	sr := beep.SampleRate(44100)
+	sr := beep.SampleRate(SampleRate)
 
 	// Create sine wave generator
 	sine := newSineWave(sr, frequency)
@@ -59,6 +62,51 @@ func PlayFrequency(frequency float64, duration time.Duration) error {
 	return nil
 }
 
+// PlayStream plays mono samples at SampleRate, pulled from read until it returns 0.
+// Any sound already playing is stopped.
+func PlayStream(read func(samples []float64) int) error {
+	if err := InitAudio(); err != nil {
+		return err
+	}
+
+	speaker.Clear()
+	speaker.Play(&monoStreamer{read: read})
+
+	return nil
+}
+
+// Stop silences everything that is playing
+func Stop() {
+	speakerMutex.Lock()
+	defer speakerMutex.Unlock()
+
+	if speakerInitialized {
+		speaker.Clear()
+	}
+}
+
+// monoStreamer copies samples from a read function to both channels
+type monoStreamer struct {
+	read   func(samples []float64) int
+	buffer []float64
+}
+
+func (m *monoStreamer) Stream(samples [][2]float64) (n int, ok bool) {
+	if len(m.buffer) < len(samples) {
+		m.buffer = make([]float64, len(samples))
+	}
+	n = m.read(m.buffer[:len(samples)])
+	for i := 0; i < n; i++ {
+		samples[i][0] = m.buffer[i]
+		samples[i][1] = m.buffer[i]
+	}
+	return n, n > 0
+}
+
+func (m *monoStreamer) Err() error {
+	return nil
+}
+
 // sineWaveStreamer generates a sine wave at the specified frequency
 type sineWaveStreamer struct {
 	frequency  float64
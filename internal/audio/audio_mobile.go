@@ -6,6 +6,9 @@ import (
 	"time"
 )
 
+// SampleRate is the rate streams are expected at
+const SampleRate = 44100
+
 // InitAudio is a no-op stub for mobile platforms
 // Audio playback is disabled on Android/iOS to avoid linker conflicts
 func InitAudio() error {
@@ -19,3 +22,13 @@ func PlayFrequency(frequency float64, duration time.Duration) error {
 	// No-op: audio is not supported on mobile to avoid Fyne/Oto conflicts
 	return nil
 }
+
+// PlayStream is a no-op stub for mobile platforms
+// Audio playback is disabled on Android/iOS to avoid linker conflicts
+func PlayStream(read func(samples []float64) int) error {
+	// No-op: audio is not supported on mobile to avoid Fyne/Oto conflicts
+	return nil
+}
+
+// Stop is a no-op stub for mobile platforms
+func Stop() {}
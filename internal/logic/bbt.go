@@ -0,0 +1,221 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TimeSignature is a meter such as 4/4 or 7/8
+type TimeSignature struct {
+	Numerator   int // Beats per bar
+	Denominator int // Note value of a beat: 2, 4, 8, 16, ...
+}
+
+func (t TimeSignature) String() string {
+	return fmt.Sprintf("%d/%d", t.Numerator, t.Denominator)
+}
+
+// ParseTimeSignature reads a meter written as "7/8"
+func ParseTimeSignature(text string) (TimeSignature, error) {
+	numerator, denominator, ok := strings.Cut(strings.TrimSpace(text), "/")
+	n, err1 := strconv.Atoi(strings.TrimSpace(numerator))
+	d, err2 := strconv.Atoi(strings.TrimSpace(denominator))
+	signature := TimeSignature{n, d}
+	if !ok || err1 != nil || err2 != nil || signature.Validate() != nil {
+		return TimeSignature{}, fmt.Errorf("invalid time signature %q", text)
+	}
+	return signature, nil
+}
+
+// Validate checks for a positive number of beats and a power-of-two note value
+func (t TimeSignature) Validate() error {
+	if t.Numerator <= 0 || t.Numerator > 99 {
+		return errors.New("beats per bar must be 1 to 99")
+	}
+	if t.Denominator <= 0 || t.Denominator > 64 || t.Denominator&(t.Denominator-1) != 0 {
+		return errors.New("beat note value must be 1, 2, 4, 8, 16, 32 or 64")
+	}
+	return nil
+}
+
+// QuarterNotes returns the length of a bar in quarter notes, e.g. 3.5 for 7/8
+func (t TimeSignature) QuarterNotes() float64 {
+	return float64(t.Numerator) * 4 / float64(t.Denominator)
+}
+
+// BBT is a bars:beats:ticks position. Bars and beats count from 1, ticks from 0;
+// bars before bar 1 are 0, -1, ... as in a DAW's pre-roll.
+type BBT struct {
+	Bar, Beat, Tick int
+}
+
+func (b BBT) String() string {
+	return fmt.Sprintf("%d|%d|%03d", b.Bar, b.Beat, b.Tick)
+}
+
+// ParseBBT reads a position such as "5|3|120", "5.3.120", "5:3" or "5 3 120".
+// Missing beats and ticks default to the start of the bar or beat.
+func ParseBBT(text string) (BBT, error) {
+	parts := strings.FieldsFunc(strings.TrimSpace(text), func(r rune) bool {
+		return r == '|' || r == '.' || r == ':' || r == ' ' || r == ','
+	})
+	if len(parts) == 0 || len(parts) > 3 {
+		return BBT{}, fmt.Errorf("invalid position %q, use bar|beat|tick", text)
+	}
+	fields := []int{1, 1, 0}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return BBT{}, fmt.Errorf("invalid position %q, use bar|beat|tick", text)
+		}
+		fields[i] = value
+	}
+	return BBT{fields[0], fields[1], fields[2]}, nil
+}
+
+// MusicalGrid is a constant tempo in a time signature at a sequencer resolution
+type MusicalGrid struct {
+	BPM       float64
+	Signature TimeSignature
+	PPQ       int  // Ticks per quarter note
+	BeatTempo bool // BPM counts the signature's beats (e.g. eighths in 7/8) instead of quarter notes
+}
+
+// CommonPPQ lists the usual sequencer resolutions
+var CommonPPQ = []string{"24", "48", "96", "192", "240", "384", "480", "960", "1920", "3840"}
+
+// Validate checks the tempo and that a beat is a whole number of ticks
+func (g MusicalGrid) Validate() error {
+	if g.BPM <= 0 || math.IsInf(g.BPM, 0) || math.IsNaN(g.BPM) {
+		return errors.New("tempo must be positive")
+	}
+	if err := g.Signature.Validate(); err != nil {
+		return err
+	}
+	if g.PPQ <= 0 {
+		return errors.New("PPQ must be positive")
+	}
+	if g.PPQ*4%g.Signature.Denominator != 0 {
+		return fmt.Errorf("a 1/%d beat isn't a whole number of ticks at %d PPQ", g.Signature.Denominator, g.PPQ)
+	}
+	return nil
+}
+
+// QuarterBPM returns the tempo in quarter notes per minute
+func (g MusicalGrid) QuarterBPM() float64 {
+	if g.BeatTempo {
+		return g.BPM * 4 / float64(g.Signature.Denominator)
+	}
+	return g.BPM
+}
+
+// TicksPerBeat returns the ticks in one beat of the time signature
+func (g MusicalGrid) TicksPerBeat() int {
+	return g.PPQ * 4 / g.Signature.Denominator
+}
+
+// TicksPerBar returns the ticks in one bar
+func (g MusicalGrid) TicksPerBar() int {
+	return g.TicksPerBeat() * g.Signature.Numerator
+}
+
+// TicksToSeconds returns the time of a tick position counted from bar 1
+func (g MusicalGrid) TicksToSeconds(ticks float64) float64 {
+	return ticks / float64(g.PPQ) * 60 / g.QuarterBPM()
+}
+
+// SecondsToTicks returns the tick position of a time counted from bar 1
+func (g MusicalGrid) SecondsToTicks(seconds float64) float64 {
+	return seconds * g.QuarterBPM() / 60 * float64(g.PPQ)
+}
+
+// BBTToTicks returns the ticks from bar 1 to a position, checking the beat and tick ranges
+func (g MusicalGrid) BBTToTicks(b BBT) (int, error) {
+	if b.Beat < 1 || b.Beat > g.Signature.Numerator {
+		return 0, fmt.Errorf("beat must be 1 to %d in %s", g.Signature.Numerator, g.Signature)
+	}
+	if b.Tick < 0 || b.Tick >= g.TicksPerBeat() {
+		return 0, fmt.Errorf("tick must be 0 to %d at %d PPQ in %s", g.TicksPerBeat()-1, g.PPQ, g.Signature)
+	}
+	return (b.Bar-1)*g.TicksPerBar() + (b.Beat-1)*g.TicksPerBeat() + b.Tick, nil
+}
+
+// TicksToBBT returns the position of a whole tick count from bar 1
+func (g MusicalGrid) TicksToBBT(ticks int) BBT {
+	perBar, perBeat := g.TicksPerBar(), g.TicksPerBeat()
+	bar := floorDiv(ticks, perBar)
+	inBar := ticks - bar*perBar
+	return BBT{Bar: bar + 1, Beat: inBar/perBeat + 1, Tick: inBar % perBeat}
+}
+
+// floorDiv divides rounding towards negative infinity, so pre-roll ticks land in bar 0
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// MusicalPosition is a point on a tempo grid in musical and absolute time
+type MusicalPosition struct {
+	BBT      BBT
+	Ticks    float64        // Exact ticks from bar 1; BBT holds them rounded to a whole tick
+	Seconds  float64        // Time from bar 1
+	Samples  int64          // Samples from bar 1, rounded
+	Timecode TimecodeResult // Frame the position falls in, with the bar 1 timecode added
+	SubFrame float64        // How far into that frame the position is, 0..1
+}
+
+// MusicalTimeConverter links positions on a tempo map to time, samples and timecode
+type MusicalTimeConverter struct {
+	Map         *TempoMap
+	Format      FPSFormat
+	SampleRate  int
+	StartFrames int // Timecode of bar 1 in frames, e.g. 01:00:00:00
+}
+
+// FromBBT converts a bars:beats:ticks position
+func (c MusicalTimeConverter) FromBBT(b BBT) (MusicalPosition, error) {
+	if err := c.Map.Validate(); err != nil {
+		return MusicalPosition{}, err
+	}
+	ticks, err := c.Map.BBTToTicks(b)
+	if err != nil {
+		return MusicalPosition{}, err
+	}
+	return c.FromSeconds(c.Map.TicksToSeconds(float64(ticks))), nil
+}
+
+// FromSeconds converts a time from bar 1
+func (c MusicalTimeConverter) FromSeconds(seconds float64) MusicalPosition {
+	ticks := c.Map.SecondsToTicks(seconds)
+
+	// Frames since 00:00:00:00; the timecode is the frame the position falls in
+	num, den := c.Format.FrameRate()
+	frames := float64(c.StartFrames) + seconds*float64(num)/float64(den)
+	// Absorb floating point noise so exact frame boundaries aren't shown as the frame before
+	frame := math.Floor(frames + 1e-9)
+
+	return MusicalPosition{
+		BBT:      c.Map.TicksToBBT(int(math.Round(ticks))),
+		Ticks:    ticks,
+		Seconds:  seconds,
+		Samples:  int64(math.Round(seconds * float64(c.SampleRate))),
+		Timecode: FramesToTimecode(int(frame), c.Format),
+		SubFrame: math.Max(0, frames-frame),
+	}
+}
+
+// FromSamples converts a sample count from bar 1
+func (c MusicalTimeConverter) FromSamples(samples int64) MusicalPosition {
+	return c.FromSeconds(float64(samples) / float64(c.SampleRate))
+}
+
+// FromTimecode converts the start of a frame, given as frames since 00:00:00:00
+func (c MusicalTimeConverter) FromTimecode(totalFrames int) MusicalPosition {
+	return c.FromSeconds(FramesToSeconds(totalFrames-c.StartFrames, c.Format))
+}
@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestTimecodeToBBT answers "what bar is 01:00:32:10 at 97 BPM in 7/8"
+func TestTimecodeToBBT(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	start, _ := ParseTimecode("01:00:00:00", format)
+	hit, _ := ParseTimecode("01:00:32:10", format)
+
+	grid := MusicalGrid{BPM: 97, Signature: TimeSignature{7, 8}, PPQ: 960}
+	converter := MusicalTimeConverter{
+		Map:         grid.TempoMap(),
+		Format:      format,
+		SampleRate:  48000,
+		StartFrames: start,
+	}
+	// 32.4 s is 52.38 quarter notes: 14 bars of 3.5 quarters, then 6.76 eighths
+	position := converter.FromTimecode(hit)
+	if position.BBT != (BBT{15, 7, 365}) || position.Samples != 1555200 {
+		t.Errorf("01:00:32:10 = %s, %d smp", position.BBT, position.Samples)
+	}
+
+	// Tempo given in eighths per minute lands on the same position
+	grid.BPM, grid.BeatTempo = 194, true
+	converter.Map = grid.TempoMap()
+	if position := converter.FromTimecode(hit); position.BBT != (BBT{15, 7, 365}) {
+		t.Errorf("194 eighths per minute = %s", position.BBT)
+	}
+}
+
+// TestBBTToTime converts positions to time, samples and timecode
+func TestBBTToTime(t *testing.T) {
+	format := GetFPSFormat("29.97 fps (df)")
+	start, _ := ParseTimecode("00:59:59;00", format)
+	converter := MusicalTimeConverter{
+		Map:         MusicalGrid{BPM: 120, Signature: TimeSignature{4, 4}, PPQ: 960}.TempoMap(),
+		Format:      format,
+		SampleRate:  48000,
+		StartFrames: start,
+	}
+
+	position, err := converter.FromBBT(BBT{5, 1, 0})
+	if err != nil {
+		t.Fatalf("FromBBT failed: %v", err)
+	}
+	// 16 beats at 120 BPM; 8 s is 239.76 frames at 29.97, and minute 60 keeps all its labels
+	if position.Seconds != 8 || position.Samples != 384000 || position.Timecode.Timecode != "01:00:06:29" {
+		t.Errorf("5|1|000 = %.3f s, %d smp, %s", position.Seconds, position.Samples, position.Timecode.Timecode)
+	}
+	if math.Abs(position.SubFrame-0.7602) > 1e-4 {
+		t.Errorf("sub-frame = %.4f", position.SubFrame)
+	}
+
+	// Half a beat before bar 1 is in the pre-roll bar 0
+	if pre := converter.FromSeconds(-0.25); pre.BBT != (BBT{0, 4, 480}) {
+		t.Errorf("-0.25 s = %s", pre.BBT)
+	}
+
+	if _, err := converter.FromBBT(BBT{2, 5, 0}); err == nil {
+		t.Error("expected an error for beat 5 in 4/4")
+	}
+	if _, err := converter.FromBBT(BBT{2, 1, 960}); err == nil {
+		t.Error("expected an error for tick 960 at 960 PPQ")
+	}
+}
+
+// TestMusicalGridValidate rejects beats that aren't whole ticks
+func TestMusicalGridValidate(t *testing.T) {
+	if err := (MusicalGrid{BPM: 120, Signature: TimeSignature{3, 64}, PPQ: 24}).Validate(); err == nil {
+		t.Error("expected an error for 1/64 beats at 24 PPQ")
+	}
+	if err := (MusicalGrid{BPM: 120, Signature: TimeSignature{5, 6}, PPQ: 960}).Validate(); err == nil {
+		t.Error("expected an error for 5/6")
+	}
+	if err := (MusicalGrid{BPM: 120, Signature: TimeSignature{5, 16}, PPQ: 96}).Validate(); err != nil {
+		t.Errorf("5/16 at 96 PPQ: %v", err)
+	}
+}
+
+// TestParseBBT reads the usual position notations
+func TestParseBBT(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected BBT
+	}{
+		{"5|3|120", BBT{5, 3, 120}},
+		{"5.3.120", BBT{5, 3, 120}},
+		{"12:2", BBT{12, 2, 0}},
+		{" 9 ", BBT{9, 1, 0}},
+		{"-1 4 240", BBT{-1, 4, 240}},
+	}
+	for _, tc := range testCases {
+		got, err := ParseBBT(tc.input)
+		if err != nil || got != tc.expected {
+			t.Errorf("ParseBBT(%q) = %v, %v", tc.input, got, err)
+		}
+	}
+	if _, err := ParseBBT("1|2|3|4"); err == nil {
+		t.Error("expected an error for four fields")
+	}
+
+	signature, err := ParseTimeSignature(" 7 / 8")
+	if err != nil || signature != (TimeSignature{7, 8}) {
+		t.Errorf("ParseTimeSignature = %v, %v", signature, err)
+	}
+}
@@ -0,0 +1,192 @@
+package logic
+
+import (
+	"errors"
+	"io"
+	"math"
+)
+
+// ClickKind is the sound used for a click
+type ClickKind int
+
+const (
+	ClickDownbeat    ClickKind = iota // First beat of a bar, accented
+	ClickBeat                         // Other beats
+	ClickSubdivision                  // Clicks between the beats
+)
+
+// clickSounds are the pitch and level of each kind of click
+var clickSounds = [...]struct {
+	Frequency float64
+	Gain      float64
+}{
+	ClickDownbeat:    {1600, 1},
+	ClickBeat:        {1000, 0.7},
+	ClickSubdivision: {1000, 0.35},
+}
+
+// clickLength and clickDecay shape each click as a decaying sine burst
+const (
+	clickLength = 0.04  // Seconds
+	clickDecay  = 0.008 // Time constant of the decay in seconds
+)
+
+// ClickOptions describes a metronome click track
+type ClickOptions struct {
+	Map         *TempoMap // Tempo and meter; a constant tempo is a one-segment map
+	Bars        int       // Bars from bar 1
+	Subdivision int       // Clicks per beat, 1 for beats only
+	CountIn     int       // Bars of count-in before bar 1, beats only
+	SampleRate  int
+	LevelDBFS   float64 // Peak level of an accented click
+}
+
+// ClickEvent is a click at a time from the start of the track
+type ClickEvent struct {
+	Seconds float64
+	Kind    ClickKind
+	Bar     int // Count-in bars are 0, -1, ...
+	Beat    int
+}
+
+// ClickEvents lists the clicks of a track in time order and returns the track length,
+// which ends on the bar line after the last bar so the track loops cleanly
+func ClickEvents(opts ClickOptions) ([]ClickEvent, float64, error) {
+	if err := opts.Map.Validate(); err != nil {
+		return nil, 0, err
+	}
+	if opts.Bars <= 0 || opts.Bars > 9999 {
+		return nil, 0, errors.New("bars must be 1 to 9999")
+	}
+	if opts.Subdivision <= 0 || opts.Subdivision > 16 {
+		return nil, 0, errors.New("subdivision must be 1 to 16 clicks per beat")
+	}
+	if opts.CountIn < 0 || opts.CountIn > 16 {
+		return nil, 0, errors.New("count-in must be 0 to 16 bars")
+	}
+
+	barTicks := func(bar int) float64 {
+		ticks, _ := opts.Map.BBTToTicks(BBT{Bar: bar, Beat: 1})
+		return float64(ticks)
+	}
+	firstBar := 1 - opts.CountIn
+	start := opts.Map.TicksToSeconds(barTicks(firstBar))
+
+	var events []ClickEvent
+	for bar := firstBar; bar <= opts.Bars; bar++ {
+		signature := opts.Map.SignatureAt(bar)
+		beatTicks := float64(opts.Map.PPQ * 4 / signature.Denominator)
+		subdivision := opts.Subdivision
+		if bar < 1 {
+			subdivision = 1
+		}
+		for beat := 0; beat < signature.Numerator; beat++ {
+			for sub := 0; sub < subdivision; sub++ {
+				ticks := barTicks(bar) + (float64(beat)+float64(sub)/float64(subdivision))*beatTicks
+				kind := ClickSubdivision
+				switch {
+				case sub > 0:
+				case beat == 0:
+					kind = ClickDownbeat
+				default:
+					kind = ClickBeat
+				}
+				events = append(events, ClickEvent{
+					Seconds: opts.Map.TicksToSeconds(ticks) - start,
+					Kind:    kind,
+					Bar:     bar,
+					Beat:    beat + 1,
+				})
+			}
+		}
+	}
+	length := opts.Map.TicksToSeconds(barTicks(opts.Bars+1)) - start
+	return events, length, nil
+}
+
+// ClickTrack renders a click track sample by sample, for playback or a WAV file
+type ClickTrack struct {
+	events     []ClickEvent
+	sampleRate int
+	gain       float64
+	length     int64 // Samples
+	position   int64
+	next       int // First event that hasn't finished sounding
+}
+
+// NewClickTrack lays out the clicks of a track
+func NewClickTrack(opts ClickOptions) (*ClickTrack, error) {
+	if opts.SampleRate <= 0 {
+		return nil, errors.New("invalid sample rate")
+	}
+	events, length, err := ClickEvents(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &ClickTrack{
+		events:     events,
+		sampleRate: opts.SampleRate,
+		gain:       DBFSToGain(opts.LevelDBFS),
+		length:     int64(math.Round(length * float64(opts.SampleRate))),
+	}, nil
+}
+
+// Length returns the track length in samples
+func (c *ClickTrack) Length() int64 {
+	return c.length
+}
+
+// Read fills buf with the next samples and returns how many were written, 0 at the end
+func (c *ClickTrack) Read(buf []float64) int {
+	rate := float64(c.sampleRate)
+	n := 0
+	for ; n < len(buf) && c.position < c.length; n++ {
+		t := float64(c.position) / rate
+
+		// Skip clicks that have died away; later ones start later
+		for c.next < len(c.events) && c.events[c.next].Seconds+clickLength <= t {
+			c.next++
+		}
+		value := 0.0
+		for _, event := range c.events[c.next:] {
+			if event.Seconds > t {
+				break
+			}
+			since := t - event.Seconds
+			if since >= clickLength {
+				continue
+			}
+			sound := clickSounds[event.Kind]
+			value += sound.Gain * math.Sin(2*math.Pi*sound.Frequency*since) * math.Exp(-since/clickDecay)
+		}
+		buf[n] = math.Max(-1, math.Min(1, value*c.gain))
+		c.position++
+	}
+	return n
+}
+
+// RenderClick writes a click track as a mono 16-bit WAV
+func RenderClick(w io.Writer, opts ClickOptions) error {
+	track, err := NewClickTrack(opts)
+	if err != nil {
+		return err
+	}
+	wav, err := NewWAVWriter(w, opts.SampleRate, 1, track.Length())
+	if err != nil {
+		return err
+	}
+
+	buf := make([]float64, 4096)
+	for {
+		n := track.Read(buf)
+		if n == 0 {
+			break
+		}
+		for _, value := range buf[:n] {
+			if err := wav.WriteSample(value); err != nil {
+				return err
+			}
+		}
+	}
+	return wav.Close()
+}
@@ -0,0 +1,122 @@
+package logic
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestClickEvents lays out a count-in, accents and subdivisions
+func TestClickEvents(t *testing.T) {
+	grid := MusicalGrid{BPM: 120, Signature: TimeSignature{4, 4}, PPQ: 960}
+	events, length, err := ClickEvents(ClickOptions{Map: grid.TempoMap(), Bars: 2, Subdivision: 2, CountIn: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One count-in bar of beats, then two bars of eighths
+	if len(events) != 4+16 {
+		t.Fatalf("got %d clicks, expected 20", len(events))
+	}
+	if length != 6 {
+		t.Errorf("length = %v s, expected 6", length)
+	}
+	expected := []ClickEvent{
+		{0, ClickDownbeat, 0, 1},
+		{0.5, ClickBeat, 0, 2},
+		{2, ClickDownbeat, 1, 1},
+		{2.25, ClickSubdivision, 1, 1},
+		{2.5, ClickBeat, 1, 2},
+	}
+	for i, event := range []ClickEvent{events[0], events[1], events[4], events[5], events[6]} {
+		if math.Abs(event.Seconds-expected[i].Seconds) > 1e-9 || event.Kind != expected[i].Kind ||
+			event.Bar != expected[i].Bar || event.Beat != expected[i].Beat {
+			t.Errorf("click %d = %+v, expected %+v", i, event, expected[i])
+		}
+	}
+}
+
+// TestClickEventsTempoMap follows meter changes and ramps
+func TestClickEventsTempoMap(t *testing.T) {
+	tempos, err := ParseTempoMap(strings.NewReader("1 120 4/4\n2 60>120 3/4 linear\n3 120 4/4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	events, length, err := ClickEvents(ClickOptions{Map: tempos, Bars: 3, Subdivision: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4+3+4 {
+		t.Fatalf("got %d clicks, expected 11", len(events))
+	}
+	if events[4].Bar != 2 || events[4].Kind != ClickDownbeat || events[4].Seconds != 2 {
+		t.Errorf("bar 2 click = %+v, expected a downbeat at 2 s", events[4])
+	}
+	// Beats get shorter through the accelerando
+	first, second := events[5].Seconds-events[4].Seconds, events[6].Seconds-events[5].Seconds
+	if first <= second || first >= 1 || second <= 0.5 {
+		t.Errorf("ramp beats = %.3f s, %.3f s, expected shrinking between 1 and 0.5 s", first, second)
+	}
+	if math.Abs(length-(tempos.TicksToSeconds(float64(11*960)))) > 1e-9 {
+		t.Errorf("length = %.3f s, expected the start of bar 4", length)
+	}
+}
+
+// TestRenderClick writes a WAV with an accented click on each bar line
+func TestRenderClick(t *testing.T) {
+	grid := MusicalGrid{BPM: 120, Signature: TimeSignature{4, 4}, PPQ: 960}
+	opts := ClickOptions{Map: grid.TempoMap(), Bars: 1, Subdivision: 1, SampleRate: 48000, LevelDBFS: -6}
+
+	var buf bytes.Buffer
+	if err := RenderClick(&buf, opts); err != nil {
+		t.Fatal(err)
+	}
+	wav, err := NewWAVReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wav.SampleRate != 48000 || wav.TotalSamples != 96000 {
+		t.Fatalf("WAV = %d Hz, %d samples, expected 48000 Hz, 96000 samples", wav.SampleRate, wav.TotalSamples)
+	}
+
+	// Peak of each beat's click within its first 40 ms, silence between clicks
+	peaks := make([]float64, 4)
+	for n := 0; n < 96000; n++ {
+		value, err := wav.ReadSample()
+		if err != nil {
+			t.Fatal(err)
+		}
+		beat, offset := n/24000, n%24000
+		switch {
+		case offset < 1920:
+			peaks[beat] = math.Max(peaks[beat], math.Abs(value))
+		case value != 0:
+			t.Fatalf("sample %d = %v, expected silence between clicks", n, value)
+		}
+	}
+	accent := DBFSToGain(-6)
+	if peaks[0] > accent+1e-3 || peaks[0] < accent*0.8 {
+		t.Errorf("downbeat peak = %.3f, expected about %.3f", peaks[0], accent)
+	}
+	if peaks[1] >= peaks[0] || peaks[1] < peaks[0]*0.5 {
+		t.Errorf("beat peak = %.3f, expected quieter than the downbeat %.3f", peaks[1], peaks[0])
+	}
+}
+
+// TestClickEventsErrors rejects empty tracks and odd subdivisions
+func TestClickEventsErrors(t *testing.T) {
+	tempos := MusicalGrid{BPM: 120, Signature: TimeSignature{4, 4}, PPQ: 960}.TempoMap()
+	for _, opts := range []ClickOptions{
+		{Map: tempos, Bars: 0, Subdivision: 1},
+		{Map: tempos, Bars: 4, Subdivision: 0},
+		{Map: tempos, Bars: 4, Subdivision: 1, CountIn: -1},
+	} {
+		if _, _, err := ClickEvents(opts); err == nil {
+			t.Errorf("%+v: expected an error", opts)
+		}
+	}
+	if _, err := NewClickTrack(ClickOptions{Map: tempos, Bars: 1, Subdivision: 1}); err == nil {
+		t.Error("no sample rate: expected an error")
+	}
+}
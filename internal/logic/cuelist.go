@@ -0,0 +1,110 @@
+package logic
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Cue is one duration in a cue list
+type Cue struct {
+	Name   string // Rest of the line besides the timecode, e.g. a clip name
+	Frames int
+}
+
+// CueList is a list of durations at one frame rate
+type CueList struct {
+	Format FPSFormat
+	Cues   []Cue
+}
+
+// CueListEntry is a cue placed in the running order
+type CueListEntry struct {
+	Cue
+	Duration     TimecodeResult
+	Start        TimecodeResult
+	End          TimecodeResult
+	RunningTotal TimecodeResult // Sum of the durations up to and including this cue
+}
+
+// cueTimecodePattern matches timecodes with ':', ';' or '.' separators, e.g. 00:00:10:12, 00;01;00;02 or 10.12
+var cueTimecodePattern = regexp.MustCompile(`-?\d+(?:[:;.]\d+){1,3}`)
+
+// ParseCueList reads one duration per line from a pasted block, e.g. a column copied
+// from a spreadsheet or an edit list. Each line's timecode may use ':', ';' or '.'
+// separators; the text around it becomes the cue name. When a line holds several
+// numbers the one with the most fields is taken. Lines without a timecode are skipped.
+func ParseCueList(text string, format FPSFormat) (*CueList, error) {
+	list := &CueList{Format: format}
+	for lineNumber, line := range strings.Split(text, "\n") {
+		matches := cueTimecodePattern.FindAllStringIndex(line, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		best := matches[0]
+		for _, match := range matches[1:] {
+			if cueFields(line[match[0]:match[1]]) > cueFields(line[best[0]:best[1]]) {
+				best = match
+			}
+		}
+
+		frames, err := ParseTimecode(line[best[0]:best[1]], format)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNumber+1, err)
+		}
+		name := strings.Join(strings.Fields(line[:best[0]]+" "+line[best[1]:]), " ")
+		list.Cues = append(list.Cues, Cue{Name: name, Frames: frames})
+	}
+	return list, nil
+}
+
+func cueFields(timecode string) int {
+	return len(strings.FieldsFunc(timecode, func(r rune) bool {
+		return r == ':' || r == ';' || r == '.'
+	}))
+}
+
+// Totals places the cues one after another from a start position and returns
+// each cue's start, end and running total along with the total duration
+func (c *CueList) Totals(start int, mode ResultMode) ([]CueListEntry, TimecodeResult) {
+	entries := make([]CueListEntry, len(c.Cues))
+	total := 0
+	for i, cue := range c.Cues {
+		entries[i] = CueListEntry{
+			Cue:      cue,
+			Duration: FramesToTimecode(cue.Frames, c.Format),
+			Start:    FramesToTimecode(ApplyResultMode(start+total, c.Format, mode), c.Format),
+		}
+		total += cue.Frames
+		entries[i].End = FramesToTimecode(ApplyResultMode(start+total, c.Format, mode), c.Format)
+		entries[i].RunningTotal = FramesToTimecode(total, c.Format)
+	}
+	return entries, FramesToTimecode(total, c.Format)
+}
+
+// Convert returns the list at another frame rate. With keepFrames every cue keeps
+// its frame count, as in a speed change (e.g. film played at 25 fps); otherwise
+// every cue keeps its running time, rounded to the nearest target frame.
+func (c *CueList) Convert(target FPSFormat, keepFrames bool) *CueList {
+	converted := &CueList{Format: target, Cues: make([]Cue, len(c.Cues))}
+	for i, cue := range c.Cues {
+		converted.Cues[i] = cue
+		if !keepFrames {
+			converted.Cues[i].Frames = SecondsToFrames(FramesToSeconds(cue.Frames, c.Format), target)
+		}
+	}
+	return converted
+}
+
+// String writes the list back as one "timecode name" line per cue
+func (c *CueList) String() string {
+	var b strings.Builder
+	for _, cue := range c.Cues {
+		b.WriteString(FramesToTimecode(cue.Frames, c.Format).Timecode)
+		if cue.Name != "" {
+			b.WriteString("  " + cue.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,79 @@
+package logic
+
+import "testing"
+
+// TestParseCueList reads mixed separators, cue names and skips header lines
+func TestParseCueList(t *testing.T) {
+	text := "Reel 1 durations\n" +
+		"00:00:10:12\tOpening v1.2\n" +
+		"Clip B  00;01;00;02\n" +
+		"\n" +
+		"10.05\n"
+	list, err := ParseCueList(text, GetFPSFormat("29.97 fps (df)"))
+	if err != nil {
+		t.Fatalf("ParseCueList failed: %v", err)
+	}
+
+	expected := []Cue{
+		{Name: "Opening v1.2", Frames: 312},
+		{Name: "Clip B", Frames: 1800},
+		{Name: "", Frames: 305},
+	}
+	if len(list.Cues) != len(expected) {
+		t.Fatalf("got %d cues, expected %d: %+v", len(list.Cues), len(expected), list.Cues)
+	}
+	for i, cue := range expected {
+		if list.Cues[i] != cue {
+			t.Errorf("cue %d = %+v, expected %+v", i, list.Cues[i], cue)
+		}
+	}
+
+	// Dropped labels are reported with their line
+	if _, err := ParseCueList("00:00:01:00\n00:01:00;00", GetFPSFormat("29.97 fps (df)")); err == nil || err.Error()[:7] != "line 2:" {
+		t.Errorf("expected a line 2 error, got %v", err)
+	}
+}
+
+// TestCueListTotals checks running start/end positions of a reel
+func TestCueListTotals(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	list, _ := ParseCueList("00:00:10:00 A\n00:00:05:20 B\n00:00:00:05 C", format)
+	start, _ := ParseTimecode("01:00:00:00", format)
+
+	entries, total := list.Totals(start, ResultSigned)
+	if total.Timecode != "00:00:16:00" {
+		t.Errorf("total = %s", total.Timecode)
+	}
+	expected := []struct{ start, end, running string }{
+		{"01:00:00:00", "01:00:10:00", "00:00:10:00"},
+		{"01:00:10:00", "01:00:15:20", "00:00:15:20"},
+		{"01:00:15:20", "01:00:16:00", "00:00:16:00"},
+	}
+	for i, e := range expected {
+		if entries[i].Start.Timecode != e.start || entries[i].End.Timecode != e.end || entries[i].RunningTotal.Timecode != e.running {
+			t.Errorf("cue %d = %s-%s (%s), expected %s-%s (%s)", i,
+				entries[i].Start.Timecode, entries[i].End.Timecode, entries[i].RunningTotal.Timecode, e.start, e.end, e.running)
+		}
+	}
+}
+
+// TestCueListConvert converts by running time and by frame count
+func TestCueListConvert(t *testing.T) {
+	list, _ := ParseCueList("00:00:10:00 A\n00:01:00:00 B", GetFPSFormat("24 fps"))
+
+	byTime := list.Convert(GetFPSFormat("25 fps"), false)
+	if got := byTime.String(); got != "00:00:10:00  A\n00:01:00:00  B\n" {
+		t.Errorf("keep running time =\n%s", got)
+	}
+
+	byFrames := list.Convert(GetFPSFormat("25 fps"), true)
+	if got := byFrames.String(); got != "00:00:09:15  A\n00:00:57:15  B\n" {
+		t.Errorf("keep frames =\n%s", got)
+	}
+
+	// 10 s is 239.76 frames at 23.976, rounded per cue
+	ntsc := list.Convert(GetFPSFormat("23.976 fps"), false)
+	if ntsc.Cues[0].Frames != 240 || ntsc.Cues[1].Frames != 1439 {
+		t.Errorf("23.976 frames = %d, %d", ntsc.Cues[0].Frames, ntsc.Cues[1].Frames)
+	}
+}
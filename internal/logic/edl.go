@@ -0,0 +1,235 @@
+package logic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// EDLEvent is a single CMX3600 event line with its timecodes in frames
+type EDLEvent struct {
+	Number     string
+	Reel       string
+	Track      string    // V, A, A2, AA/V, B, ...
+	Transition string    // C, D 030, W001 015, ...
+	ClipName   string    // From a "* FROM CLIP NAME:" comment, if present
+	DropFrame  bool      // FCM in effect for this event
+	Format     FPSFormat // Frame rate the event's timecodes were read at
+	SourceIn   int
+	SourceOut  int
+	RecordIn   int
+	RecordOut  int
+	Duration   int // Record out - record in
+}
+
+// EDL holds a parsed CMX3600 edit decision list
+type EDL struct {
+	Title     string
+	DropFrame bool      // First FCM header in the list
+	Format    FPSFormat // Frame rate the timecodes were read at
+	Events    []EDLEvent
+}
+
+// EDLGap is a hole or an overlap between consecutive events on a record channel
+type EDLGap struct {
+	Track  string // Channel: V or A1 to A4 and beyond
+	After  string // Event number ending before the gap
+	Before string // Event number starting after the gap
+	Start  int    // Record frame where the gap (or overlap) starts
+	End    int    // Record frame where it ends
+}
+
+// EDLAnalysis summarizes the record timeline of an EDL
+type EDLAnalysis struct {
+	ProgramStart    int
+	ProgramEnd      int
+	ProgramDuration int // Last record out - first record in
+	EventsDuration  int // Sum of all event durations
+	Gaps            []EDLGap
+	Overlaps        []EDLGap
+}
+
+// ParseEDL reads a CMX3600 EDL. The frame rate comes from the given format;
+// FCM headers switch between its drop-frame and non-drop-frame variants, so a
+// drop-frame EDL read at 30 fps is counted at 29.97 fps (df).
+func ParseEDL(r io.Reader, format FPSFormat) (*EDL, error) {
+	edl := &EDL{Format: format, DropFrame: format.DropFrame}
+	current := format
+	seenFCM := false
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		upper := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(upper, "TITLE:"):
+			edl.Title = strings.TrimSpace(line[len("TITLE:"):])
+			continue
+		case strings.HasPrefix(upper, "FCM:"):
+			dropFrame := !strings.Contains(upper, "NON") && strings.Contains(upper, "DROP")
+			var err error
+			if current, err = edlFCMFormat(format, dropFrame); err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			if !seenFCM {
+				edl.DropFrame = dropFrame
+				edl.Format = current
+				seenFCM = true
+			}
+			continue
+		case strings.HasPrefix(line, "*"):
+			// Comments attach to the preceding event
+			comment := strings.TrimSpace(line[1:])
+			if len(edl.Events) > 0 && strings.HasPrefix(strings.ToUpper(comment), "FROM CLIP NAME:") {
+				edl.Events[len(edl.Events)-1].ClipName = strings.TrimSpace(comment[len("FROM CLIP NAME:"):])
+			}
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 8 || !isEDLEventNumber(fields[0]) {
+			// Motion effects (M2), split edits and other notes aren't events
+			continue
+		}
+
+		event := EDLEvent{
+			Number:     fields[0],
+			Reel:       fields[1],
+			Track:      fields[2],
+			Transition: strings.Join(fields[3:len(fields)-4], " "),
+			DropFrame:  current.DropFrame,
+			Format:     current,
+		}
+
+		timecodes := [4]int{}
+		for i, text := range fields[len(fields)-4:] {
+			frames, err := ParseTimecode(text, current)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNumber, err)
+			}
+			timecodes[i] = frames
+		}
+		event.SourceIn, event.SourceOut = timecodes[0], timecodes[1]
+		event.RecordIn, event.RecordOut = timecodes[2], timecodes[3]
+		event.Duration = event.RecordOut - event.RecordIn
+
+		edl.Events = append(edl.Events, event)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(edl.Events) == 0 {
+		return nil, fmt.Errorf("no events found")
+	}
+
+	return edl, nil
+}
+
+// edlFCMFormat returns the format an FCM header selects. Drop frame only exists at
+// 29.97 and 59.94 fps, so it is picked by nominal rate and other rates are an error.
+func edlFCMFormat(format FPSFormat, dropFrame bool) (FPSFormat, error) {
+	if !dropFrame {
+		return DropFrameVariant(format, false), nil
+	}
+	for _, candidate := range FPSFormats {
+		if candidate.DropFrame && candidate.NominalRate() == format.NominalRate() {
+			return candidate, nil
+		}
+	}
+	return format, fmt.Errorf("drop-frame EDL can't be read at %s, choose 29.97 or 59.94 fps", format.Name)
+}
+
+func isEDLEventNumber(field string) bool {
+	for _, r := range field {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return field != ""
+}
+
+// edlChannels expands a track field into the channels it records on:
+// A is A1, AA is A1 and A2, B is V and A1, and AA/V is V, A1 and A2.
+// Fields it doesn't know are a channel of their own.
+func edlChannels(track string) []string {
+	var channels []string
+	for _, part := range strings.Split(strings.ToUpper(track), "/") {
+		expanded := []string{part}
+		switch part {
+		case "A":
+			expanded = []string{"A1"}
+		case "AA":
+			expanded = []string{"A1", "A2"}
+		case "B":
+			expanded = []string{"V", "A1"}
+		}
+		for _, channel := range expanded {
+			if channel != "" && !slices.Contains(channels, channel) {
+				channels = append(channels, channel)
+			}
+		}
+	}
+	return channels
+}
+
+// Analyze totals the event durations and finds gaps and overlaps per record channel,
+// so a B (picture and sound) event fills both the V and the A1 lane.
+// Zero-length events (the outgoing side of a dissolve) don't occupy the timeline.
+func (e *EDL) Analyze() EDLAnalysis {
+	analysis := EDLAnalysis{}
+
+	tracks := map[string][]EDLEvent{}
+	var trackNames []string
+	for i, event := range e.Events {
+		analysis.EventsDuration += event.Duration
+		if i == 0 || event.RecordIn < analysis.ProgramStart {
+			analysis.ProgramStart = event.RecordIn
+		}
+		if i == 0 || event.RecordOut > analysis.ProgramEnd {
+			analysis.ProgramEnd = event.RecordOut
+		}
+
+		if event.Duration <= 0 {
+			continue
+		}
+		for _, channel := range edlChannels(event.Track) {
+			if _, ok := tracks[channel]; !ok {
+				trackNames = append(trackNames, channel)
+			}
+			tracks[channel] = append(tracks[channel], event)
+		}
+	}
+	analysis.ProgramDuration = analysis.ProgramEnd - analysis.ProgramStart
+
+	for _, track := range trackNames {
+		events := tracks[track]
+		sort.SliceStable(events, func(i, j int) bool { return events[i].RecordIn < events[j].RecordIn })
+
+		for i := 1; i < len(events); i++ {
+			prev, next := events[i-1], events[i]
+			switch {
+			case next.RecordIn > prev.RecordOut:
+				analysis.Gaps = append(analysis.Gaps, EDLGap{
+					Track: track, After: prev.Number, Before: next.Number,
+					Start: prev.RecordOut, End: next.RecordIn,
+				})
+			case next.RecordIn < prev.RecordOut:
+				analysis.Overlaps = append(analysis.Overlaps, EDLGap{
+					Track: track, After: prev.Number, Before: next.Number,
+					Start: next.RecordIn, End: prev.RecordOut,
+				})
+			}
+		}
+	}
+
+	return analysis
+}
@@ -0,0 +1,133 @@
+package logic
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testEDL = `TITLE: REEL 1 CONFORM
+FCM: NON-DROP FRAME
+
+001  TAPE1    V     C        01:00:00:00 01:00:05:00 00:59:58:00 01:00:03:00
+* FROM CLIP NAME: SLATE.MOV
+002  TAPE2    V     C        02:10:00:00 02:10:10:00 01:00:03:00 01:00:13:00
+003  TAPE2    V     C        02:20:00:00 02:20:00:00 01:00:15:00 01:00:15:00
+003  TAPE3    V     D    030 03:00:00:00 03:00:05:00 01:00:15:00 01:00:20:00
+M2   TAPE3       050.0                   03:00:00:00
+004  TAPE1    A     C        01:00:00:00 01:00:20:00 00:59:58:00 01:00:18:00
+005  TAPE1    A     C        01:01:00:00 01:01:05:00 01:00:17:00 01:00:22:00
+`
+
+// TestParseEDL checks event fields, clip names and per-track gap and overlap detection
+func TestParseEDL(t *testing.T) {
+	edl, err := ParseEDL(strings.NewReader(testEDL), GetFPSFormat("25 fps"))
+	if err != nil {
+		t.Fatalf("ParseEDL failed: %v", err)
+	}
+
+	if edl.Title != "REEL 1 CONFORM" || edl.DropFrame {
+		t.Errorf("header = %q df=%v, expected REEL 1 CONFORM non-drop", edl.Title, edl.DropFrame)
+	}
+	if len(edl.Events) != 6 {
+		t.Fatalf("got %d events, expected 6", len(edl.Events))
+	}
+	if edl.Events[0].ClipName != "SLATE.MOV" {
+		t.Errorf("clip name = %q, expected SLATE.MOV", edl.Events[0].ClipName)
+	}
+	if edl.Events[3].Transition != "D 030" || edl.Events[3].Duration != 125 {
+		t.Errorf("dissolve = %q %d frames, expected D 030 125 frames", edl.Events[3].Transition, edl.Events[3].Duration)
+	}
+
+	analysis := edl.Analyze()
+	if got := FramesToTimecode(analysis.ProgramDuration, edl.Format).Timecode; got != "00:00:24:00" {
+		t.Errorf("program duration = %s, expected 00:00:24:00", got)
+	}
+	if analysis.EventsDuration != 125+250+0+125+500+125 {
+		t.Errorf("events duration = %d", analysis.EventsDuration)
+	}
+
+	// V: 002 ends at 01:00:13:00, the dissolve starts at 01:00:15:00
+	if len(analysis.Gaps) != 1 || analysis.Gaps[0].Track != "V" || analysis.Gaps[0].End-analysis.Gaps[0].Start != 50 {
+		t.Errorf("gaps = %+v, expected one 50-frame gap on V", analysis.Gaps)
+	}
+	// A1: 005 starts one second before 004 ends
+	if len(analysis.Overlaps) != 1 || analysis.Overlaps[0].Track != "A1" || analysis.Overlaps[0].Before != "005" || analysis.Overlaps[0].End-analysis.Overlaps[0].Start != 25 {
+		t.Errorf("overlaps = %+v, expected one 25-frame overlap before 005", analysis.Overlaps)
+	}
+}
+
+// TestAnalyzeEDLChannels checks that combined tracks fill every channel they record on
+func TestAnalyzeEDLChannels(t *testing.T) {
+	text := `FCM: NON-DROP FRAME
+001  AX  V     C  00:00:00:00 00:00:00:10 01:00:00:00 01:00:00:10
+002  AX  B     C  00:00:00:00 00:00:00:10 01:00:00:10 01:00:00:20
+003  AX  V     C  00:00:00:00 00:00:00:10 01:00:00:20 01:00:01:05
+004  AX  AA/V  C  00:00:00:00 00:00:00:10 01:00:01:05 01:00:01:15
+005  AX  A2    C  00:00:00:00 00:00:00:10 01:00:01:10 01:00:01:20
+`
+	edl, err := ParseEDL(strings.NewReader(text), GetFPSFormat("25 fps"))
+	if err != nil {
+		t.Fatalf("ParseEDL failed: %v", err)
+	}
+	analysis := edl.Analyze()
+
+	// No picture gap on V; A1 starts with 002 and jumps from 002 to 004
+	if len(analysis.Gaps) != 1 || analysis.Gaps[0].Track != "A1" || analysis.Gaps[0].After != "002" || analysis.Gaps[0].Before != "004" {
+		t.Errorf("gaps = %+v, expected one gap on A1 from 002 to 004", analysis.Gaps)
+	}
+	// A2: 005 starts 5 frames before 004 ends
+	if len(analysis.Overlaps) != 1 || analysis.Overlaps[0].Track != "A2" || analysis.Overlaps[0].End-analysis.Overlaps[0].Start != 5 {
+		t.Errorf("overlaps = %+v, expected one 5-frame overlap on A2", analysis.Overlaps)
+	}
+
+	if got := edlChannels("AA/V"); !slices.Equal(got, []string{"A1", "A2", "V"}) {
+		t.Errorf("AA/V channels = %v", got)
+	}
+}
+
+// TestParseEDLDropFrame checks that FCM switches to the drop-frame variant of the frame rate
+func TestParseEDLDropFrame(t *testing.T) {
+	text := "TITLE: DF\nFCM: DROP FRAME\n001  AX  V  C  00:00:00;00 00:02:00;02 01:00:00;00 01:02:00;02\n"
+	edl, err := ParseEDL(strings.NewReader(text), GetFPSFormat("29.97 fps"))
+	if err != nil {
+		t.Fatalf("ParseEDL failed: %v", err)
+	}
+	if !edl.DropFrame || !edl.Format.DropFrame {
+		t.Errorf("expected drop-frame format, got %s", edl.Format.Name)
+	}
+	// A tenth minute of 1800 frames followed by a dropped minute of 1798 frames
+	if edl.Events[0].Duration != 3598 {
+		t.Errorf("duration = %d, expected 3598", edl.Events[0].Duration)
+	}
+
+	// The timecode tab's default 30 fps reads drop-frame lists at 29.97 fps (df)
+	edl, err = ParseEDL(strings.NewReader(text), GetFPSFormat("30 fps"))
+	if err != nil {
+		t.Fatalf("ParseEDL at 30 fps failed: %v", err)
+	}
+	if edl.Format.Name != "29.97 fps (df)" || edl.Events[0].Duration != 3598 {
+		t.Errorf("30 fps: got %s, duration %d, expected 29.97 fps (df), 3598", edl.Format.Name, edl.Events[0].Duration)
+	}
+	// A second FCM header switches the format of the events after it
+	mixed := "FCM: NON-DROP FRAME\n001  AX  V  C  00:00:00:00 00:00:01:00 01:00:00:00 01:00:01:00\n" + text
+	edl, err = ParseEDL(strings.NewReader(mixed), GetFPSFormat("29.97 fps"))
+	if err != nil {
+		t.Fatalf("ParseEDL of mixed FCM failed: %v", err)
+	}
+	if edl.DropFrame || edl.Events[0].Format.DropFrame || edl.Events[1].Format.Name != "29.97 fps (df)" {
+		t.Errorf("mixed FCM: got %s then %s, expected 29.97 fps then 29.97 fps (df)", edl.Events[0].Format.Name, edl.Events[1].Format.Name)
+	}
+
+	text60 := "FCM: DROP FRAME\n001  AX  V  C  00:00:00;00 00:02:00;04 01:00:00;00 01:02:00;04\n"
+	if edl, err := ParseEDL(strings.NewReader(text60), GetFPSFormat("60 fps")); err != nil || edl.Format.Name != "59.94 fps (df)" {
+		t.Errorf("60 fps: expected 59.94 fps (df), got %v", err)
+	}
+	if _, err := ParseEDL(strings.NewReader(text), GetFPSFormat("25 fps")); err == nil {
+		t.Error("drop-frame EDL at 25 fps: expected an error")
+	}
+
+	if _, err := ParseEDL(strings.NewReader("TITLE: EMPTY\n"), GetFPSFormat("25 fps")); err == nil {
+		t.Error("expected an error for an EDL without events")
+	}
+}
@@ -0,0 +1,116 @@
+package logic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FilmFormat describes how frames are laid out on a foot of film
+type FilmFormat struct {
+	Name          string
+	PerfsPerFoot  int
+	PerfsPerFrame int
+}
+
+// FilmFormats lists common gauges and pulldowns.
+// Formats whose frames don't divide a foot evenly (3-perf, 5-perf) count feet
+// in repeating cycles, e.g. 3-perf uses 22+21+21 frames over 3 feet.
+var FilmFormats = []FilmFormat{
+	{"35mm 4-perf", 64, 4}, // 16 frames per foot
+	{"35mm 3-perf", 64, 3}, // 21⅓ frames per foot
+	{"35mm 2-perf", 64, 2}, // 32 frames per foot
+	{"16mm", 40, 1},        // 40 frames per foot
+	{"65mm 5-perf", 64, 5}, // 12.8 frames per foot
+}
+
+func GetFilmFormat(name string) FilmFormat {
+	for _, film := range FilmFormats {
+		if film.Name == name {
+			return film
+		}
+	}
+	return FilmFormats[0] // Default to 35mm 4-perf
+}
+
+// FootageResult holds a position in feet+frames
+type FootageResult struct {
+	Feet        int
+	Frames      int
+	TotalFrames int
+	Negative    bool // Feet and frames hold the magnitude of a negative position
+	Footage     string
+}
+
+// footStartFrame returns the first frame that starts within the given foot
+func footStartFrame(feet int, film FilmFormat) int {
+	perfs := feet * film.PerfsPerFoot
+	return (perfs + film.PerfsPerFrame - 1) / film.PerfsPerFrame
+}
+
+// FeetToFrames converts feet+frames to total frames
+func FeetToFrames(feet, frames int, film FilmFormat) int {
+	return footStartFrame(feet, film) + frames
+}
+
+// FramesToFeet converts total frames to feet+frames
+func FramesToFeet(totalFrames int, film FilmFormat) FootageResult {
+	result := FootageResult{TotalFrames: totalFrames}
+
+	if totalFrames < 0 {
+		result.Negative = true
+		totalFrames = -totalFrames
+	}
+
+	result.Feet = totalFrames * film.PerfsPerFrame / film.PerfsPerFoot
+	result.Frames = totalFrames - footStartFrame(result.Feet, film)
+
+	result.Footage = fmt.Sprintf("%d+%02d", result.Feet, result.Frames)
+	if result.Negative {
+		result.Footage = "-" + result.Footage
+	}
+	return result
+}
+
+// FeetToTimecode converts feet+frames to a timecode at the given frame rate
+func FeetToTimecode(feet, frames int, film FilmFormat, format FPSFormat) TimecodeResult {
+	return FramesToTimecode(FeetToFrames(feet, frames, film), format)
+}
+
+// TimecodeToFeet converts a timecode at the given frame rate to feet+frames
+func TimecodeToFeet(hours, minutes, seconds, frames int, format FPSFormat, film FilmFormat) FootageResult {
+	return FramesToFeet(TimecodeToFrames(hours, minutes, seconds, frames, format), film)
+}
+
+// ParseFootage reads feet+frames written as "123+04", "123.04" or "123:04"
+// and returns the total frame count. A plain number is read as feet.
+func ParseFootage(text string, film FilmFormat) (totalFrames int, ok bool) {
+	text = strings.TrimSpace(text)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == '+' || r == '.' || r == ',' || r == ':' || r == ' '
+	})
+	if len(parts) == 0 || len(parts) > 2 {
+		return 0, false
+	}
+
+	feet, err := strconv.Atoi(parts[0])
+	if err != nil || feet < 0 {
+		return 0, false
+	}
+	frames := 0
+	if len(parts) == 2 {
+		frames, err = strconv.Atoi(parts[1])
+		if err != nil || frames < 0 {
+			return 0, false
+		}
+	}
+
+	totalFrames = FeetToFrames(feet, frames, film)
+	if negative {
+		totalFrames = -totalFrames
+	}
+	return totalFrames, true
+}
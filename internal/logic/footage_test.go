@@ -0,0 +1,57 @@
+package logic
+
+import "testing"
+
+// TestFramesToFeet checks feet+frames against the standard perforation counts
+func TestFramesToFeet(t *testing.T) {
+	testCases := []struct {
+		filmName    string
+		totalFrames int
+		expected    string
+	}{
+		{"35mm 4-perf", 16, "1+00"},
+		{"35mm 4-perf", 1439, "89+15"},
+		{"35mm 3-perf", 21, "0+21"}, // First foot of the 3-foot cycle holds 22 frames
+		{"35mm 3-perf", 22, "1+00"},
+		{"35mm 3-perf", 64, "3+00"},
+		{"35mm 2-perf", 33, "1+01"},
+		{"16mm", 40, "1+00"},
+		{"16mm", 79, "1+39"},
+		{"65mm 5-perf", 12, "0+12"}, // First foot of the 5-foot cycle holds 13 frames
+		{"65mm 5-perf", 13, "1+00"},
+		{"65mm 5-perf", 64, "5+00"},
+		{"35mm 4-perf", -20, "-1+04"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.filmName+" "+tc.expected, func(t *testing.T) {
+			film := GetFilmFormat(tc.filmName)
+			result := FramesToFeet(tc.totalFrames, film)
+			if result.Footage != tc.expected {
+				t.Errorf("FramesToFeet(%d) = %s, expected %s", tc.totalFrames, result.Footage, tc.expected)
+			}
+
+			back, ok := ParseFootage(result.Footage, film)
+			if !ok || back != tc.totalFrames {
+				t.Errorf("ParseFootage(%s) = %d, expected %d", result.Footage, back, tc.totalFrames)
+			}
+		})
+	}
+}
+
+// TestFootageTimecode checks footage to timecode conversion at 24 fps
+func TestFootageTimecode(t *testing.T) {
+	format := GetFPSFormat("24 fps")
+	film := GetFilmFormat("35mm 4-perf")
+
+	// 90 feet of 4-perf is exactly one minute at 24 fps
+	result := FeetToTimecode(90, 0, film, format)
+	if result.Timecode != "00:01:00:00" {
+		t.Errorf("90+00 = %s, expected 00:01:00:00", result.Timecode)
+	}
+
+	footage := TimecodeToFeet(1, 0, 0, 0, format, film)
+	if footage.Footage != "5400+00" {
+		t.Errorf("01:00:00:00 = %s, expected 5400+00", footage.Footage)
+	}
+}
@@ -0,0 +1,169 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// HitPointMaxSteps limits how many tempos one search tries
+const HitPointMaxSteps = 100000
+
+// HitPointSearch describes a tempo search for hit points in picture
+type HitPointSearch struct {
+	Format      FPSFormat
+	Start       int   // Timecode of the first click, in frames
+	Hits        []int // Hit timecodes, in frames
+	MinBPM      float64
+	MaxBPM      float64
+	StepBPM     float64 // Tempo resolution of the search, e.g. 0.01
+	MaxOffsetMS float64 // The first click may move this far from Start; 0 keeps it on Start
+	BeatsPerBar int
+	Results     int // Number of candidates to return
+}
+
+// HitPointMatch is where a hit lands on a candidate tempo's click
+type HitPointMatch struct {
+	Hit         int // Hit timecode, in frames
+	Beat        int // Clicks after the first one, which is beat 0
+	Bar         int // Bar and beat of the click, counting from 1
+	BarBeat     int
+	ErrorMS     float64 // Positive when the hit comes after the click
+	ErrorFrames float64
+}
+
+// TempoCandidate is a tempo and click offset ranked by how well the hits land on clicks
+type TempoCandidate struct {
+	BPM          float64
+	OffsetMS     float64 // First click relative to the start timecode
+	OffsetFrames float64
+	FrameClick   string // Frames per beat in film click notation
+	TotalErrorMS float64
+	MaxErrorMS   float64
+	Matches      []HitPointMatch
+}
+
+// FrameClick writes the beat length in frames and eighths of a frame, as in film click books:
+// "12-0" is 12 frames per beat (120 BPM at 24 fps), "11-6" is 11 6/8 frames
+func FrameClick(bpm float64, format FPSFormat) string {
+	eighths := int(math.Round(format.FPS * 60 / bpm * 8))
+	return fmt.Sprintf("%d-%d", eighths/8, eighths%8)
+}
+
+// FindHitPointTempos ranks the tempos in a BPM range by the total timing error of the hits.
+// For each tempo the click offset is chosen to minimize the error; only the tempos that
+// beat their neighbours in the search are kept, so the results are distinct choices.
+func FindHitPointTempos(search HitPointSearch) ([]TempoCandidate, error) {
+	if len(search.Hits) == 0 {
+		return nil, errors.New("no hit points")
+	}
+	if search.MinBPM <= 0 || search.MaxBPM < search.MinBPM || search.StepBPM <= 0 {
+		return nil, errors.New("invalid BPM range")
+	}
+	if (search.MaxBPM-search.MinBPM)/search.StepBPM > HitPointMaxSteps {
+		return nil, fmt.Errorf("BPM range too large for the step, at most %d steps", HitPointMaxSteps)
+	}
+	if search.BeatsPerBar <= 0 {
+		search.BeatsPerBar = 4
+	}
+
+	// Hit times from the start timecode
+	times := make([]float64, len(search.Hits))
+	for i, hit := range search.Hits {
+		if hit < search.Start {
+			return nil, fmt.Errorf("hit %s is before the start", FramesToTimecode(hit, search.Format).Timecode)
+		}
+		times[i] = FramesToSeconds(hit-search.Start, search.Format) * 1000
+	}
+
+	steps := int(math.Round((search.MaxBPM - search.MinBPM) / search.StepBPM))
+	candidates := make([]TempoCandidate, steps+1)
+	for i := range candidates {
+		bpm := search.MinBPM + float64(i)*search.StepBPM
+		candidates[i] = bestClickOffset(bpm, times, search.MaxOffsetMS)
+	}
+
+	// Keep the local minima of the error over the BPM range
+	var ranked []TempoCandidate
+	for i, candidate := range candidates {
+		if i > 0 && candidates[i-1].TotalErrorMS < candidate.TotalErrorMS {
+			continue
+		}
+		if i+1 < len(candidates) && candidates[i+1].TotalErrorMS <= candidate.TotalErrorMS {
+			continue
+		}
+		ranked = append(ranked, candidate)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].TotalErrorMS < ranked[j].TotalErrorMS })
+	if search.Results > 0 && len(ranked) > search.Results {
+		ranked = ranked[:search.Results]
+	}
+
+	for i := range ranked {
+		ranked[i] = matchHitPoints(ranked[i], search, times)
+	}
+	return ranked, nil
+}
+
+// clickError returns the signed distance in ms from a hit to the nearest click and the click's index
+func clickError(timeMS, offsetMS, beatMS float64) (float64, int) {
+	beat := math.Round((timeMS - offsetMS) / beatMS)
+	return timeMS - offsetMS - beat*beatMS, int(beat)
+}
+
+// bestClickOffset finds the click offset with the lowest total error for a tempo.
+// The total is piecewise linear in the offset, so its minimum is at an offset that
+// puts some hit exactly on a click, or at the edge of the allowed range.
+func bestClickOffset(bpm float64, times []float64, maxOffsetMS float64) TempoCandidate {
+	beatMS := GetTempoData(bpm, 1).DelayMS
+	maxOffsetMS = math.Min(math.Abs(maxOffsetMS), beatMS/2)
+
+	offsets := []float64{0}
+	if maxOffsetMS > 0 {
+		offsets = append(offsets, -maxOffsetMS, maxOffsetMS)
+		for _, t := range times {
+			if offset, _ := clickError(t, 0, beatMS); math.Abs(offset) <= maxOffsetMS {
+				offsets = append(offsets, offset)
+			}
+		}
+	}
+
+	best := TempoCandidate{BPM: bpm, TotalErrorMS: math.Inf(1)}
+	for _, offset := range offsets {
+		total := 0.0
+		for _, t := range times {
+			e, _ := clickError(t, offset, beatMS)
+			total += math.Abs(e)
+		}
+		// Prefer smaller offsets between equal totals
+		if total < best.TotalErrorMS-1e-9 || (math.Abs(total-best.TotalErrorMS) <= 1e-9 && math.Abs(offset) < math.Abs(best.OffsetMS)) {
+			best.TotalErrorMS, best.OffsetMS = total, offset
+		}
+	}
+	return best
+}
+
+// matchHitPoints fills in where each hit lands for a ranked candidate
+func matchHitPoints(candidate TempoCandidate, search HitPointSearch, times []float64) TempoCandidate {
+	beatMS := GetTempoData(candidate.BPM, 1).DelayMS
+	msPerFrame := 1000 / search.Format.FPS
+
+	candidate.OffsetFrames = candidate.OffsetMS / msPerFrame
+	candidate.FrameClick = FrameClick(candidate.BPM, search.Format)
+	candidate.MaxErrorMS = 0
+	candidate.Matches = make([]HitPointMatch, len(times))
+	for i, t := range times {
+		e, beat := clickError(t, candidate.OffsetMS, beatMS)
+		candidate.Matches[i] = HitPointMatch{
+			Hit:         search.Hits[i],
+			Beat:        beat,
+			Bar:         floorDiv(beat, search.BeatsPerBar) + 1,
+			BarBeat:     beat - floorDiv(beat, search.BeatsPerBar)*search.BeatsPerBar + 1,
+			ErrorMS:     e,
+			ErrorFrames: e / msPerFrame,
+		}
+		candidate.MaxErrorMS = math.Max(candidate.MaxErrorMS, math.Abs(e))
+	}
+	return candidate
+}
@@ -0,0 +1,105 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFrameClick writes beat lengths in frames and eighths
+func TestFrameClick(t *testing.T) {
+	format := GetFPSFormat("24 fps")
+	if got := FrameClick(120, format); got != "12-0" {
+		t.Errorf("120 BPM at 24 fps = %s, want 12-0", got)
+	}
+	if got := FrameClick(122.45, format); got != "11-6" {
+		t.Errorf("122.45 BPM at 24 fps = %s, want 11-6", got)
+	}
+}
+
+// TestFindHitPointTempos finds the tempo that puts every hit on a click
+func TestFindHitPointTempos(t *testing.T) {
+	format := GetFPSFormat("24 fps")
+	start := TimecodeToFrames(1, 0, 0, 0, format)
+
+	// Hits on beats 2, 4 and 8 of a 120 BPM click (12 frames per beat)
+	search := HitPointSearch{
+		Format:  format,
+		Start:   start,
+		Hits:    []int{start + 24, start + 48, start + 96},
+		MinBPM:  110,
+		MaxBPM:  130,
+		StepBPM: 0.5,
+		Results: 3,
+	}
+	candidates, err := FindHitPointTempos(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := candidates[0]
+	if best.BPM != 120 || best.TotalErrorMS > 1e-9 || best.OffsetMS != 0 || best.FrameClick != "12-0" {
+		t.Fatalf("best = %.2f BPM offset %.1f ms error %.3f ms %s, want 120 BPM on the start", best.BPM, best.OffsetMS, best.TotalErrorMS, best.FrameClick)
+	}
+	last := best.Matches[2]
+	if last.Beat != 8 || last.Bar != 3 || last.BarBeat != 1 {
+		t.Errorf("last hit = beat %d (%d|%d), want beat 8 (3|1)", last.Beat, last.Bar, last.BarBeat)
+	}
+	for i := 1; i < len(candidates); i++ {
+		if candidates[i].TotalErrorMS < candidates[i-1].TotalErrorMS {
+			t.Errorf("candidates not ranked by error: %v", candidates)
+		}
+	}
+
+	// Other candidates report their errors in ms and frames
+	other := candidates[1].Matches[0]
+	if math.Abs(other.ErrorFrames-other.ErrorMS*24/1000) > 1e-9 {
+		t.Errorf("error %.3f frames doesn't match %.3f ms", other.ErrorFrames, other.ErrorMS)
+	}
+}
+
+// TestFindHitPointTemposOffset moves the first click to line up late hits
+func TestFindHitPointTemposOffset(t *testing.T) {
+	format := GetFPSFormat("24 fps")
+
+	// The same hits 3 frames (125 ms) late only line up when the click may move
+	search := HitPointSearch{
+		Format:  format,
+		Hits:    []int{27, 51, 99},
+		MinBPM:  110,
+		MaxBPM:  130,
+		StepBPM: 0.5,
+	}
+	candidates, err := FindHitPointTempos(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if candidates[0].TotalErrorMS < 1 {
+		t.Errorf("fixed click = %.2f BPM with %.3f ms error, want a nonzero error", candidates[0].BPM, candidates[0].TotalErrorMS)
+	}
+
+	search.MaxOffsetMS = 200
+	candidates, err = FindHitPointTempos(search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	best := candidates[0]
+	if best.BPM != 120 || best.TotalErrorMS > 1e-6 || math.Abs(best.OffsetMS-125) > 1e-6 || math.Abs(best.OffsetFrames-3) > 1e-6 {
+		t.Errorf("best = %.2f BPM offset %.3f ms (%.3f frames) error %.3f ms, want 120 BPM offset 125 ms", best.BPM, best.OffsetMS, best.OffsetFrames, best.TotalErrorMS)
+	}
+}
+
+// TestFindHitPointTemposErrors rejects empty, early, reversed and oversized searches
+func TestFindHitPointTemposErrors(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	if _, err := FindHitPointTempos(HitPointSearch{Format: format, MinBPM: 60, MaxBPM: 120, StepBPM: 1}); err == nil {
+		t.Error("no hits: want an error")
+	}
+	if _, err := FindHitPointTempos(HitPointSearch{Format: format, Start: 100, Hits: []int{50}, MinBPM: 60, MaxBPM: 120, StepBPM: 1}); err == nil {
+		t.Error("hit before start: want an error")
+	}
+	if _, err := FindHitPointTempos(HitPointSearch{Format: format, Hits: []int{50}, MinBPM: 120, MaxBPM: 60, StepBPM: 1}); err == nil {
+		t.Error("reversed range: want an error")
+	}
+	if _, err := FindHitPointTempos(HitPointSearch{Format: format, Hits: []int{50}, MinBPM: 1, MaxBPM: 140, StepBPM: 0.001}); err == nil {
+		t.Error("139000 steps: want an error")
+	}
+}
@@ -0,0 +1,174 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LTCFrameBits is the number of bits in one SMPTE linear timecode frame
+const LTCFrameBits = 80
+
+// ltcSyncWord occupies bits 64-79 of every frame: 0011 1111 1111 1101
+var ltcSyncWord = [16]bool{false, false, true, true, true, true, true, true, true, true, true, true, true, true, false, true}
+
+// LTCOptions describes an LTC recording
+type LTCOptions struct {
+	Format     FPSFormat
+	SampleRate int
+	StartFrame int     // Timecode of the first frame as a frame count
+	Frames     int     // Duration in frames
+	UserBits   uint32  // User bit groups 1-8, group 1 in the lowest nibble
+	LevelDBFS  float64 // Peak level of the square wave
+}
+
+// LTCSupported reports whether LTC is defined for the frame rate (24, 25 and 30 fps and their NTSC variants)
+func LTCSupported(format FPSFormat) bool {
+	switch format.NominalRate() {
+	case 24, 25, 30:
+		return true
+	}
+	return false
+}
+
+// ltcPolarityBit returns the bit that keeps the number of zeros in a frame even.
+// At 25 fps it moves from bit 27 to bit 59, where 30 fps keeps a binary group flag.
+func ltcPolarityBit(format FPSFormat) int {
+	if format.NominalRate() == 25 {
+		return 59
+	}
+	return 27
+}
+
+// EncodeLTCFrame returns the 80 bits of the LTC frame for a timecode, in transmission order
+func EncodeLTCFrame(totalFrames int, format FPSFormat, userBits uint32) [LTCFrameBits]bool {
+	var bits [LTCFrameBits]bool
+	tc := FramesToTimecode(ApplyResultMode(totalFrames, format, ResultWrap24h), format)
+
+	// BCD digits (LSB first) and user bit groups interleave in 4-bit slots
+	put := func(start, count, value int) {
+		for i := 0; i < count; i++ {
+			bits[start+i] = value&(1<<i) != 0
+		}
+	}
+	put(0, 4, tc.Frames%10)
+	put(8, 2, tc.Frames/10)
+	put(16, 4, tc.Seconds%10)
+	put(24, 3, tc.Seconds/10)
+	put(32, 4, tc.Minutes%10)
+	put(40, 3, tc.Minutes/10)
+	put(48, 4, tc.Hours%10)
+	put(56, 2, tc.Hours/10)
+	for group := 0; group < 8; group++ {
+		put(4+8*group, 4, int(userBits>>(4*group))&0xF)
+	}
+	bits[10] = format.DropFrame
+	copy(bits[64:], ltcSyncWord[:])
+
+	zeros := 0
+	for _, bit := range bits {
+		if !bit {
+			zeros++
+		}
+	}
+	// The polarity bit is still zero here; setting it removes one zero
+	bits[ltcPolarityBit(format)] = zeros%2 == 1
+
+	return bits
+}
+
+// RenderLTC writes a mono 16-bit WAV of biphase-mark LTC.
+// Every bit starts with a level change and ones change level again halfway,
+// with edges ramped over about 25 µs as SMPTE 12M asks for.
+func RenderLTC(w io.Writer, opts LTCOptions) error {
+	if !LTCSupported(opts.Format) {
+		return fmt.Errorf("LTC is only defined for 24, 25 and 30 fps, not %s", opts.Format.Name)
+	}
+	if opts.Frames <= 0 {
+		return errors.New("duration must be at least one frame")
+	}
+
+	totalSamples := FrameStartSample(opts.Frames, opts.Format, opts.SampleRate)
+	wav, err := NewWAVWriter(w, opts.SampleRate, 1, totalSamples)
+	if err != nil {
+		return err
+	}
+
+	num, den := opts.Format.FrameRate()
+	samplesPerHalfBit := float64(opts.SampleRate) * float64(den) / float64(num*2*LTCFrameBits)
+	rampHalf := math.Max(0.5, 25e-6*float64(opts.SampleRate)/2)
+	gain := DBFSToGain(opts.LevelDBFS)
+
+	frame := -1
+	var bits [LTCFrameBits]bool
+	// transition reports whether the level changes at the start of a half bit
+	transition := func(halfBit int64) bool {
+		if halfBit%2 == 0 {
+			return true
+		}
+		if f := int(halfBit / (2 * LTCFrameBits)); f != frame {
+			frame = f
+			bits = EncodeLTCFrame(opts.StartFrame+frame, opts.Format, opts.UserBits)
+		}
+		return bits[halfBit%(2*LTCFrameBits)/2]
+	}
+
+	halfBit := int64(0)
+	level, previous := 1.0, -1.0 // Level in the current half bit and the one before
+	for n := int64(0); n < totalSamples; n++ {
+		x := float64(n) / samplesPerHalfBit
+		for int64(x) > halfBit {
+			halfBit++
+			previous = level
+			if transition(halfBit) {
+				level = -level
+			}
+		}
+
+		value := level
+		sinceEdge := (x - float64(halfBit)) * samplesPerHalfBit
+		untilEdge := samplesPerHalfBit - sinceEdge
+		switch {
+		case sinceEdge < rampHalf && previous != level:
+			value = previous + (level-previous)*(0.5+sinceEdge/(2*rampHalf))
+		case untilEdge < rampHalf && transition(halfBit+1):
+			value = level - 2*level*(0.5-untilEdge/(2*rampHalf))
+		}
+
+		if err := wav.WriteSample(value * gain); err != nil {
+			return err
+		}
+	}
+
+	return wav.Close()
+}
+
+// ParseUserBits reads eight hex digits, group 8 first (e.g. "12 34 56 78");
+// spaces, colons and dashes between digits are ignored
+func ParseUserBits(text string) (uint32, error) {
+	digits := strings.Map(func(r rune) rune {
+		if r == ' ' || r == ':' || r == '-' || r == '.' {
+			return -1
+		}
+		return r
+	}, text)
+	if digits == "" {
+		return 0, nil
+	}
+	if len(digits) > 8 {
+		return 0, fmt.Errorf("user bits hold 8 hex digits, got %d", len(digits))
+	}
+	value, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid user bits %q", text)
+	}
+	return uint32(value), nil
+}
+
+// FormatUserBits writes user bits as four hex pairs, group 8 first
+func FormatUserBits(userBits uint32) string {
+	return fmt.Sprintf("%02X %02X %02X %02X", userBits>>24, userBits>>16&0xFF, userBits>>8&0xFF, userBits&0xFF)
+}
@@ -0,0 +1,114 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// ltcField reads count bits starting at start as an LSB-first number
+func ltcField(bits [LTCFrameBits]bool, start, count int) int {
+	value := 0
+	for i := 0; i < count; i++ {
+		if bits[start+i] {
+			value |= 1 << i
+		}
+	}
+	return value
+}
+
+// TestEncodeLTCFrame checks the BCD layout, user bits, flags, sync word and polarity bit
+func TestEncodeLTCFrame(t *testing.T) {
+	format := GetFPSFormat("29.97 fps (df)")
+	frames, err := ParseTimecode("12:34:56;28", format)
+	if err != nil {
+		t.Fatalf("ParseTimecode failed: %v", err)
+	}
+	bits := EncodeLTCFrame(frames, format, 0x87654321)
+
+	fields := []struct {
+		name       string
+		start, len int
+		expected   int
+	}{
+		{"frame units", 0, 4, 8},
+		{"frame tens", 8, 2, 2},
+		{"second units", 16, 4, 6},
+		{"second tens", 24, 3, 5},
+		{"minute units", 32, 4, 4},
+		{"minute tens", 40, 3, 3},
+		{"hour units", 48, 4, 2},
+		{"hour tens", 56, 2, 1},
+		{"user bits 1", 4, 4, 1},
+		{"user bits 8", 60, 4, 8},
+		{"sync word", 64, 16, 0xBFFC},
+	}
+	for _, f := range fields {
+		if got := ltcField(bits, f.start, f.len); got != f.expected {
+			t.Errorf("%s = %#x, expected %#x", f.name, got, f.expected)
+		}
+	}
+	if !bits[10] {
+		t.Error("drop-frame flag not set")
+	}
+
+	// Every frame must hold an even number of zeros at 25 and 30 fps
+	for _, name := range []string{"25 fps", "30 fps", "29.97 fps (df)"} {
+		format := GetFPSFormat(name)
+		for frame := 0; frame < 100; frame++ {
+			zeros := 0
+			for _, bit := range EncodeLTCFrame(frame*37, format, uint32(frame)) {
+				if !bit {
+					zeros++
+				}
+			}
+			if zeros%2 != 0 {
+				t.Fatalf("%s frame %d has %d zeros", name, frame*37, zeros)
+			}
+		}
+	}
+}
+
+// TestRenderLTC checks the WAV size and that every frame starts on the same polarity
+func TestRenderLTC(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	var out bytes.Buffer
+	err := RenderLTC(&out, LTCOptions{Format: format, SampleRate: 48000, Frames: 50, LevelDBFS: -6})
+	if err != nil {
+		t.Fatalf("RenderLTC failed: %v", err)
+	}
+
+	const headerSize = 44
+	if out.Len() != headerSize+2*48000*2 {
+		t.Fatalf("WAV is %d bytes, expected %d", out.Len(), headerSize+2*48000*2)
+	}
+	samples := make([]int16, 2*48000)
+	if err := binary.Read(bytes.NewReader(out.Bytes()[headerSize:]), binary.LittleEndian, samples); err != nil {
+		t.Fatalf("reading samples failed: %v", err)
+	}
+
+	// 1920 samples per frame; the middle of the first half bit has the same sign in every frame
+	for frame := 0; frame < 50; frame++ {
+		if sample := samples[frame*1920+6]; sample <= 0 {
+			t.Fatalf("frame %d starts with level %d, expected positive", frame, sample)
+		}
+	}
+
+	if err := RenderLTC(&out, LTCOptions{Format: GetFPSFormat("50 fps"), SampleRate: 48000, Frames: 1}); err == nil {
+		t.Error("expected an error for 50 fps LTC")
+	}
+}
+
+// TestParseUserBits checks hex parsing with separators
+func TestParseUserBits(t *testing.T) {
+	ub, err := ParseUserBits("87 65:43-21")
+	if err != nil || ub != 0x87654321 {
+		t.Errorf("ParseUserBits = %#x, %v", ub, err)
+	}
+	if FormatUserBits(ub) != "87 65 43 21" {
+		t.Errorf("FormatUserBits = %s", FormatUserBits(ub))
+	}
+	if _, err := ParseUserBits("123456789"); err == nil {
+		t.Error("expected an error for nine digits")
+	}
+}
@@ -0,0 +1,342 @@
+package logic
+
+import (
+	"errors"
+	"io"
+	"math"
+)
+
+// LTCFrame is one decoded LTC frame
+type LTCFrame struct {
+	Hours, Minutes, Seconds, Frames int
+	DropFrame                       bool
+	UserBits                        uint32
+	Sample                          int64 // Sample where the frame's first bit starts
+}
+
+// LTCEventKind tells dropouts from timecode jumps
+type LTCEventKind int
+
+const (
+	LTCDropout       LTCEventKind = iota // No readable LTC between Sample and EndSample
+	LTCDiscontinuity                     // Timecode doesn't follow on from the previous frame
+)
+
+// LTCEvent is a dropout or discontinuity found while decoding
+type LTCEvent struct {
+	Kind      LTCEventKind
+	Sample    int64
+	EndSample int64
+	From      TimecodeResult // Last frame before the event
+	To        TimecodeResult // First frame after the event
+}
+
+// LTCDecodeResult summarizes the LTC found in a recording
+type LTCDecodeResult struct {
+	SampleRate   int
+	TotalSamples int64
+	FrameCount   int            // Frames decoded
+	First        LTCFrame       // First frame confirmed by the one after it
+	FirstTC      TimecodeResult // First frame as a timecode at the detected rate
+	Format       FPSFormat      // Detected frame rate
+	MeasuredFPS  float64        // Frame rate measured from the frame spacing
+	Events       []LTCEvent
+}
+
+// ltcBitReader turns biphase-mark edges into bits.
+// Intervals close to a bit period are zeros, pairs of half periods are ones.
+type ltcBitReader struct {
+	level     float64 // Current signal polarity after hysteresis, +1, -1 or 0 before the first edge
+	envelope  float64 // Decaying peak level for the hysteresis threshold
+	previous  float64 // Previous sample
+	zeroCross float64 // Position of the latest zero crossing
+	lastEdge  float64 // Position of the latest accepted edge
+	bitPeriod float64 // Running estimate of one bit in samples
+	halfStart float64 // Start of a pending half-period pair, or -1
+	onBit     func(bit bool, start float64)
+	onLost    func() // Called when the bit stream breaks off
+}
+
+func newLTCBitReader(sampleRate int, onBit func(bool, float64), onLost func()) *ltcBitReader {
+	return &ltcBitReader{
+		lastEdge:  -1,
+		bitPeriod: float64(sampleRate) / (30 * LTCFrameBits), // Adapts to 24 and 25 fps after a few bits
+		halfStart: -1,
+		onBit:     onBit,
+		onLost:    onLost,
+	}
+}
+
+func (b *ltcBitReader) sample(n int64, x float64) {
+	// A signal that stops leaves the last one bit without its closing edge
+	if b.halfStart >= 0 && float64(n)-b.lastEdge > 1.5*b.bitPeriod {
+		b.flush()
+	}
+
+	switch {
+	case x*b.previous < 0:
+		b.zeroCross = float64(n-1) + b.previous/(b.previous-x)
+	case x == 0 && b.previous != 0:
+		b.zeroCross = float64(n)
+	case b.previous == 0 && x != 0:
+		b.zeroCross = float64(n - 1)
+	}
+	b.previous = x
+
+	b.envelope = math.Max(math.Abs(x), b.envelope*0.9995)
+	threshold := math.Max(0.002, 0.25*b.envelope)
+	if math.Abs(x) < threshold || (x > 0) == (b.level > 0) {
+		return
+	}
+	if x > 0 {
+		b.level = 1
+	} else {
+		b.level = -1
+	}
+
+	// Signal starting out of silence has no zero crossing, the edge is here
+	position := b.zeroCross
+	if position <= b.lastEdge {
+		position = float64(n)
+	}
+	b.edge(position)
+}
+
+// flush completes a pending one bit with the edge half a bit after its middle,
+// for the end of the file or a signal running into silence
+func (b *ltcBitReader) flush() {
+	if b.halfStart >= 0 {
+		b.edge(b.lastEdge + b.bitPeriod/2)
+	}
+}
+
+func (b *ltcBitReader) edge(position float64) {
+	if b.lastEdge < 0 {
+		b.lastEdge = position
+		return
+	}
+	interval := position - b.lastEdge
+	start := b.lastEdge
+	b.lastEdge = position
+
+	switch {
+	case interval > 1.5*b.bitPeriod || interval < 0.25*b.bitPeriod:
+		// Too long or too short for LTC: noise or a dropout, resynchronize
+		b.halfStart = -1
+		b.onLost()
+		if interval > 0.8*b.bitPeriod && interval < 2*b.bitPeriod {
+			b.bitPeriod = 0.9*b.bitPeriod + 0.1*interval
+		}
+	case interval > 0.75*b.bitPeriod:
+		b.bitPeriod = 0.95*b.bitPeriod + 0.05*interval
+		if b.halfStart >= 0 {
+			// A lone half period isn't biphase mark
+			b.halfStart = -1
+			b.onLost()
+		}
+		b.onBit(false, start)
+	case b.halfStart < 0:
+		b.halfStart = start
+	default:
+		b.bitPeriod = 0.95*b.bitPeriod + 0.05*(position-b.halfStart)
+		b.onBit(true, b.halfStart)
+		b.halfStart = -1
+	}
+}
+
+// decodeLTCBits reads the timecode from the 64 data bits of a frame, or reports false for invalid BCD
+func decodeLTCBits(bits []bool) (LTCFrame, bool) {
+	get := func(start, count int) int {
+		value := 0
+		for i := 0; i < count; i++ {
+			if bits[start+i] {
+				value |= 1 << i
+			}
+		}
+		return value
+	}
+
+	digits := []struct{ value, max int }{
+		{get(0, 4), 9}, {get(8, 2), 3},
+		{get(16, 4), 9}, {get(24, 3), 5},
+		{get(32, 4), 9}, {get(40, 3), 5},
+		{get(48, 4), 9}, {get(56, 2), 2},
+	}
+	for _, digit := range digits {
+		if digit.value > digit.max {
+			return LTCFrame{}, false
+		}
+	}
+
+	frame := LTCFrame{
+		Frames:    digits[1].value*10 + digits[0].value,
+		Seconds:   digits[3].value*10 + digits[2].value,
+		Minutes:   digits[5].value*10 + digits[4].value,
+		Hours:     digits[7].value*10 + digits[6].value,
+		DropFrame: bits[10],
+	}
+	if frame.Hours > 23 {
+		return LTCFrame{}, false
+	}
+	for group := 0; group < 8; group++ {
+		frame.UserBits |= uint32(get(4+8*group, 4)) << (4 * group)
+	}
+	return frame, true
+}
+
+// DecodeLTC scans the first channel of a WAV file for LTC.
+// The frame rate is measured from the spacing of the frames; the frame
+// labels and the drop-frame flag decide between 24, 25 and 30 fps variants.
+func DecodeLTC(r io.Reader) (*LTCDecodeResult, error) {
+	wav, err := NewWAVReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var frames []LTCFrame
+	var bits [LTCFrameBits]bool
+	var starts [LTCFrameBits]float64
+	received := 0
+	reader := newLTCBitReader(wav.SampleRate, func(bit bool, start float64) {
+		copy(bits[:], bits[1:])
+		copy(starts[:], starts[1:])
+		bits[LTCFrameBits-1], starts[LTCFrameBits-1] = bit, start
+		received++
+		if received < LTCFrameBits || [16]bool(bits[LTCFrameBits-16:]) != ltcSyncWord {
+			return
+		}
+		if frame, ok := decodeLTCBits(bits[:64]); ok {
+			frame.Sample = int64(math.Round(starts[0]))
+			frames = append(frames, frame)
+		}
+	}, func() {
+		// Frames need 80 unbroken bits
+		received = 0
+	})
+
+	n := int64(0)
+	for ; ; n++ {
+		x, err := wav.ReadSample()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		reader.sample(n, x)
+	}
+	reader.flush()
+
+	if len(frames) == 0 {
+		return nil, errors.New("no LTC found")
+	}
+
+	result := &LTCDecodeResult{
+		SampleRate:   wav.SampleRate,
+		TotalSamples: n,
+		FrameCount:   len(frames),
+	}
+	result.Format, result.MeasuredFPS = detectLTCFormat(frames, wav.SampleRate)
+
+	count := func(f LTCFrame) int {
+		return TimecodeToFrames(f.Hours, f.Minutes, f.Seconds, f.Frames, result.Format)
+	}
+	timecode := func(f LTCFrame) TimecodeResult {
+		return FramesToTimecode(count(f), result.Format)
+	}
+
+	result.First = frames[0]
+	for i := 0; i+1 < len(frames); i++ {
+		if ApplyResultMode(count(frames[i])+1, result.Format, ResultWrap24h) == count(frames[i+1]) {
+			result.First = frames[i]
+			break
+		}
+	}
+	result.FirstTC = timecode(result.First)
+
+	frameSamples := float64(wav.SampleRate) / result.MeasuredFPS
+	for i := 1; i < len(frames); i++ {
+		prev, next := frames[i-1], frames[i]
+		gap := float64(next.Sample - prev.Sample)
+		elapsed := int(math.Round(gap / frameSamples))
+
+		if gap > 1.5*frameSamples {
+			result.Events = append(result.Events, LTCEvent{
+				Kind:      LTCDropout,
+				Sample:    prev.Sample + int64(frameSamples),
+				EndSample: next.Sample,
+				From:      timecode(prev),
+				To:        timecode(next),
+			})
+		}
+		if ApplyResultMode(count(prev)+elapsed, result.Format, ResultWrap24h) != count(next) {
+			result.Events = append(result.Events, LTCEvent{
+				Kind:      LTCDiscontinuity,
+				Sample:    next.Sample,
+				EndSample: next.Sample,
+				From:      timecode(prev),
+				To:        timecode(next),
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// detectLTCFormat measures the frame rate from frames that follow each other
+// and picks the closest LTC rate with a matching drop-frame flag
+func detectLTCFormat(frames []LTCFrame, sampleRate int) (FPSFormat, float64) {
+	maxLabel := 0
+	dropFrames := 0
+	for _, frame := range frames {
+		if frame.Frames > maxLabel {
+			maxLabel = frame.Frames
+		}
+		if frame.DropFrame {
+			dropFrames++
+		}
+	}
+	dropFrame := dropFrames*2 > len(frames)
+
+	// Spacing of neighbouring frames; LTC at 24 fps is the slowest
+	span, pairs := 0.0, 0
+	longest := float64(sampleRate) / 23
+	for i := 1; i < len(frames); i++ {
+		gap := float64(frames[i].Sample - frames[i-1].Sample)
+		if gap < longest*1.1 {
+			span += gap
+			pairs++
+		}
+	}
+	measured := 0.0
+	if pairs > 0 {
+		measured = float64(pairs) * float64(sampleRate) / span
+	}
+
+	candidates := []string{"23.976 fps", "24 fps", "25 fps", "29.97 fps", "30 fps"}
+	if dropFrame {
+		candidates = []string{"29.97 fps (df)"}
+	}
+
+	best := GetFPSFormat(candidates[0])
+	bestError := math.Inf(1)
+	for _, name := range candidates {
+		format := GetFPSFormat(name)
+		// Frame labels rule out rates that are too slow
+		if maxLabel >= format.NominalRate() {
+			continue
+		}
+		rateError := math.Abs(format.FPS - measured)
+		if measured == 0 {
+			// A single frame: go by the labels alone, preferring the lowest rate
+			rateError = float64(format.NominalRate())
+		}
+		if rateError < bestError {
+			best, bestError = format, rateError
+		}
+	}
+	if measured == 0 {
+		measured = best.FPS
+	}
+	return best, measured
+}
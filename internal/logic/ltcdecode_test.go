@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+// renderLTCSamples renders LTC and returns its samples
+func renderLTCSamples(t *testing.T, opts LTCOptions) []float64 {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := RenderLTC(&buf, opts); err != nil {
+		t.Fatalf("RenderLTC failed: %v", err)
+	}
+	wav, err := NewWAVReader(&buf)
+	if err != nil {
+		t.Fatalf("NewWAVReader failed: %v", err)
+	}
+	var samples []float64
+	for {
+		x, err := wav.ReadSample()
+		if err == io.EOF {
+			return samples
+		}
+		samples = append(samples, x)
+	}
+}
+
+// TestDecodeLTCRates decodes generated LTC at every LTC frame rate
+func TestDecodeLTCRates(t *testing.T) {
+	for _, name := range []string{"23.976 fps", "24 fps", "25 fps", "29.97 fps", "29.97 fps (df)", "30 fps"} {
+		t.Run(name, func(t *testing.T) {
+			format := GetFPSFormat(name)
+			start, _ := ParseTimecode("10:00:59:20", format)
+			var buf bytes.Buffer
+			err := RenderLTC(&buf, LTCOptions{Format: format, SampleRate: 44100, StartFrame: start, Frames: 3 * format.NominalRate(), UserBits: 0x20261016, LevelDBFS: -18})
+			if err != nil {
+				t.Fatalf("RenderLTC failed: %v", err)
+			}
+
+			result, err := DecodeLTC(&buf)
+			if err != nil {
+				t.Fatalf("DecodeLTC failed: %v", err)
+			}
+			if result.Format.Name != name {
+				t.Errorf("detected %s (%.4f fps), expected %s", result.Format.Name, result.MeasuredFPS, name)
+			}
+			if result.FirstTC.TotalFrames != start || result.First.Sample > 2 {
+				t.Errorf("first frame %s at sample %d, expected 10:00:59:20 at 0", result.FirstTC.Timecode, result.First.Sample)
+			}
+			if result.First.UserBits != 0x20261016 || result.First.DropFrame != format.DropFrame {
+				t.Errorf("user bits %08X df=%v", result.First.UserBits, result.First.DropFrame)
+			}
+			// The last bit of the file is completed without its closing edge
+			if result.FrameCount != 3*format.NominalRate() || len(result.Events) != 0 {
+				t.Errorf("decoded %d frames with events %+v", result.FrameCount, result.Events)
+			}
+		})
+	}
+}
+
+// TestDecodeLTCEvents checks that a silent gap and a timecode jump are reported with sample positions
+func TestDecodeLTCEvents(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	part1 := renderLTCSamples(t, LTCOptions{Format: format, SampleRate: 48000, StartFrame: 90000, Frames: 25})
+	// Continues after 10 frames of silence, so the labels still match the elapsed time
+	part2 := renderLTCSamples(t, LTCOptions{Format: format, SampleRate: 48000, StartFrame: 90035, Frames: 25})
+	// Jumps to another take
+	part3 := renderLTCSamples(t, LTCOptions{Format: format, SampleRate: 48000, StartFrame: 180000, Frames: 25})
+
+	var buf bytes.Buffer
+	gap := make([]float64, 10*1920)
+	total := int64(len(part1) + len(gap) + len(part2) + len(part3))
+	wav, _ := NewWAVWriter(&buf, 48000, 1, total)
+	for _, part := range [][]float64{part1, gap, part2, part3} {
+		for _, x := range part {
+			wav.WriteSample(x)
+		}
+	}
+	wav.Close()
+
+	result, err := DecodeLTC(&buf)
+	if err != nil {
+		t.Fatalf("DecodeLTC failed: %v", err)
+	}
+	if len(result.Events) != 2 {
+		t.Fatalf("got events %+v, expected a dropout and a discontinuity", result.Events)
+	}
+
+	// The frame running into the silence is still read, the dropout starts after it
+	dropout := result.Events[0]
+	if dropout.Kind != LTCDropout || dropout.Sample != 25*1920 || dropout.EndSample != 35*1920 || dropout.From.Timecode != "01:00:00:24" {
+		t.Errorf("dropout = %+v, expected 01:00:00:24 then samples 48000-67200", dropout)
+	}
+	if result.FrameCount != 75 {
+		t.Errorf("decoded %d frames, expected 75", result.FrameCount)
+	}
+	jump := result.Events[1]
+	if jump.Kind != LTCDiscontinuity || jump.Sample != 60*1920 || jump.From.Timecode != "01:00:02:09" || jump.To.Timecode != "02:00:00:00" {
+		t.Errorf("discontinuity = %+v, expected 01:00:02:09 → 02:00:00:00 at sample 115200", jump)
+	}
+}
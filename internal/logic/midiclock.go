@@ -0,0 +1,75 @@
+package logic
+
+import "errors"
+
+// MIDIClockPPQ is the resolution of MIDI beat clock: 24 clock messages per quarter note
+const MIDIClockPPQ = 24
+
+// MIDIClockResult holds the timing of MIDI clock and sequencer ticks at a tempo
+type MIDIClockResult struct {
+	ClockMS       float64 // One MIDI clock message
+	ClockSamples  float64
+	ClocksPerBeat float64 // Per beat of the time signature
+	ClocksPerBar  float64
+
+	TickMS       float64 // One sequencer tick at the PPQ
+	TickSamples  float64
+	TicksPerBeat int
+	TicksPerBar  int
+
+	QuantizationMS     float64 // Largest error placing a tick on the sample grid, half a sample
+	QuantizationTicks  float64 // The same error as a fraction of a tick
+	SampleAccurate     bool    // Every tick falls on a different sample
+	BufferMS           float64 // Length of one audio buffer, the jitter of events handled once per buffer
+	BufferClocks       float64
+	BufferTicks        float64
+	JitterMinBPM       float64 // Tempo measured between two clocks that are a buffer late or early
+	JitterMaxBPM       float64
+	ClockJitterPercent float64 // Buffer length relative to the clock interval
+}
+
+// CalculateMIDIClock derives MIDI clock and tick timing from a tempo in quarter notes per minute.
+// A clock receiver that only sees events once per audio buffer can be a buffer late, so the
+// tempo it measures between two clocks swings between JitterMinBPM and JitterMaxBPM.
+func CalculateMIDIClock(bpm float64, ppq int, signature TimeSignature, sampleRate, bufferSize int) (MIDIClockResult, error) {
+	grid := MusicalGrid{BPM: bpm, Signature: signature, PPQ: ppq}
+	if err := grid.Validate(); err != nil {
+		return MIDIClockResult{}, err
+	}
+	if sampleRate <= 0 {
+		return MIDIClockResult{}, errors.New("invalid sample rate")
+	}
+	if bufferSize < 0 {
+		return MIDIClockResult{}, errors.New("buffer size can't be negative")
+	}
+
+	quarterMS := GetTempoData(bpm, 1).DelayMS
+	samplesPerMS := float64(sampleRate) / 1000
+	clocksPerBeat := float64(MIDIClockPPQ*4) / float64(signature.Denominator)
+
+	r := MIDIClockResult{
+		ClockMS:       quarterMS / MIDIClockPPQ,
+		ClocksPerBeat: clocksPerBeat,
+		ClocksPerBar:  clocksPerBeat * float64(signature.Numerator),
+		TickMS:        quarterMS / float64(ppq),
+		TicksPerBeat:  grid.TicksPerBeat(),
+		TicksPerBar:   grid.TicksPerBar(),
+		BufferMS:      float64(bufferSize) / samplesPerMS,
+	}
+	r.ClockSamples = r.ClockMS * samplesPerMS
+	r.TickSamples = r.TickMS * samplesPerMS
+	r.QuantizationMS = 0.5 / samplesPerMS
+	r.QuantizationTicks = r.QuantizationMS / r.TickMS
+	r.SampleAccurate = r.TickSamples >= 1
+	r.BufferClocks = r.BufferMS / r.ClockMS
+	r.BufferTicks = r.BufferMS / r.TickMS
+	r.ClockJitterPercent = r.BufferMS / r.ClockMS * 100
+
+	// A clock a buffer late stretches one interval and shortens the next
+	r.JitterMinBPM = 60000 / ((r.ClockMS + r.BufferMS) * MIDIClockPPQ)
+	r.JitterMaxBPM = 0 // Unbounded when a buffer is longer than a clock interval
+	if r.BufferMS < r.ClockMS {
+		r.JitterMaxBPM = 60000 / ((r.ClockMS - r.BufferMS) * MIDIClockPPQ)
+	}
+	return r, nil
+}
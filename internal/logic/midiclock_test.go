@@ -0,0 +1,75 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateMIDIClock derives clock, tick and buffer timing at 120 BPM
+func TestCalculateMIDIClock(t *testing.T) {
+	r, err := CalculateMIDIClock(120, 960, TimeSignature{4, 4}, 48000, 256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checks := []struct {
+		name          string
+		got, expected float64
+	}{
+		{"clock ms", r.ClockMS, 500.0 / 24},
+		{"clock samples", r.ClockSamples, 1000},
+		{"clocks per bar", r.ClocksPerBar, 96},
+		{"tick ms", r.TickMS, 500.0 / 960},
+		{"tick samples", r.TickSamples, 25},
+		{"quantization ms", r.QuantizationMS, 1.0 / 96},
+		{"quantization ticks", r.QuantizationTicks, 0.02},
+		{"buffer ms", r.BufferMS, 16.0 / 3},
+		{"buffer clocks", r.BufferClocks, 0.256},
+		{"buffer ticks", r.BufferTicks, 10.24},
+		{"jitter min BPM", r.JitterMinBPM, 60000 / ((500.0/24 + 16.0/3) * 24)},
+		{"jitter max BPM", r.JitterMaxBPM, 60000 / ((500.0/24 - 16.0/3) * 24)},
+	}
+	for _, c := range checks {
+		if math.Abs(c.got-c.expected) > 1e-9 {
+			t.Errorf("%s = %v, expected %v", c.name, c.got, c.expected)
+		}
+	}
+	if r.TicksPerBar != 3840 || !r.SampleAccurate {
+		t.Errorf("ticks per bar %d, sample accurate %v, expected 3840 and true", r.TicksPerBar, r.SampleAccurate)
+	}
+}
+
+// TestCalculateMIDIClockMeter counts clocks per beat of the time signature
+func TestCalculateMIDIClockMeter(t *testing.T) {
+	r, err := CalculateMIDIClock(90, 96, TimeSignature{7, 8}, 44100, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ClocksPerBeat != 12 || r.ClocksPerBar != 84 || r.TicksPerBar != 336 {
+		t.Errorf("7/8 = %v clocks per beat, %v per bar, %d ticks per bar, expected 12, 84, 336",
+			r.ClocksPerBeat, r.ClocksPerBar, r.TicksPerBar)
+	}
+	if r.BufferMS != 0 || r.JitterMinBPM != 90 || math.Abs(r.JitterMaxBPM-90) > 1e-9 {
+		t.Errorf("no buffer = %v ms, %v–%v BPM, expected no jitter", r.BufferMS, r.JitterMinBPM, r.JitterMaxBPM)
+	}
+}
+
+// TestCalculateMIDIClockLimits flags ticks shorter than a sample and buffers longer than a clock
+func TestCalculateMIDIClockLimits(t *testing.T) {
+	r, err := CalculateMIDIClock(300, 3840, TimeSignature{4, 4}, 11025, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.SampleAccurate || r.QuantizationTicks <= 0.5 {
+		t.Errorf("tick of %.3f samples: sample accurate %v, quantization %.2f ticks", r.TickSamples, r.SampleAccurate, r.QuantizationTicks)
+	}
+	if r.JitterMaxBPM != 0 || r.BufferClocks <= 1 {
+		t.Errorf("buffer of %.2f clocks: max BPM %v, expected unbounded (0)", r.BufferClocks, r.JitterMaxBPM)
+	}
+
+	if _, err := CalculateMIDIClock(120, 0, TimeSignature{4, 4}, 48000, 256); err == nil {
+		t.Error("PPQ 0: expected an error")
+	}
+	if _, err := CalculateMIDIClock(120, 960, TimeSignature{4, 4}, 0, 256); err == nil {
+		t.Error("no sample rate: expected an error")
+	}
+}
@@ -0,0 +1,230 @@
+package logic
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MTC rate codes carried in the hours byte of full-frame and quarter-frame messages
+const (
+	MTCRate24   = 0
+	MTCRate25   = 1
+	MTCRate30DF = 2 // 29.97 drop frame
+	MTCRate30   = 3
+)
+
+// MTCRateNames names the MTC rate codes
+var MTCRateNames = []string{"24 fps", "25 fps", "29.97 fps (df)", "30 fps"}
+
+// MTCKind tells full-frame messages from quarter-frame sequences
+type MTCKind int
+
+const (
+	MTCFullFrame MTCKind = iota
+	MTCQuarterFrames
+)
+
+// MTCTimecode is a timecode decoded from MTC bytes
+type MTCTimecode struct {
+	Kind     MTCKind
+	Offset   int // Byte offset of the (first) message in the input
+	RateCode int
+	Format   FPSFormat
+	Timecode TimecodeResult
+	// Position is where the receiver is once the message is complete: quarter
+	// frames take two frames to send, so it's two frames past the timecode, or
+	// two frames before it for a reverse sequence.
+	Position TimecodeResult
+	Reverse  bool   // Quarter frames sent 7 to 0, as while rewinding
+	Invalid  string // Why the fields aren't a timecode at the rate, empty when valid
+}
+
+// MTCRateCode returns the MTC rate code for a frame rate.
+// MTC has no pull-down codes: 23.976 is sent as 24 and 29.97 non-drop as 30.
+func MTCRateCode(format FPSFormat) (int, error) {
+	switch format.NominalRate() {
+	case 24:
+		return MTCRate24, nil
+	case 25:
+		return MTCRate25, nil
+	case 30:
+		if format.DropFrame {
+			return MTCRate30DF, nil
+		}
+		return MTCRate30, nil
+	}
+	return 0, fmt.Errorf("MTC only carries 24, 25 and 30 fps, not %s", format.Name)
+}
+
+// mtcFormat returns the frame rate for a rate code, keeping the hint when it
+// shares the code (so 23.976 isn't turned into 24)
+func mtcFormat(rateCode int, hint FPSFormat) FPSFormat {
+	if code, err := MTCRateCode(hint); err == nil && code == rateCode {
+		return hint
+	}
+	return GetFPSFormat(MTCRateNames[rateCode])
+}
+
+// EncodeMTCFullFrame returns the full-frame SysEx message F0 7F <device> 01 01 hh mm ss ff F7.
+// Device ID 0x7F addresses all devices.
+func EncodeMTCFullFrame(totalFrames int, format FPSFormat, deviceID byte) ([]byte, error) {
+	rateCode, err := MTCRateCode(format)
+	if err != nil {
+		return nil, err
+	}
+	tc := FramesToTimecode(ApplyResultMode(totalFrames, format, ResultWrap24h), format)
+	return []byte{
+		0xF0, 0x7F, deviceID & 0x7F, 0x01, 0x01,
+		byte(rateCode<<5 | tc.Hours), byte(tc.Minutes), byte(tc.Seconds), byte(tc.Frames),
+		0xF7,
+	}, nil
+}
+
+// EncodeMTCQuarterFrames returns the eight F1 0n quarter-frame messages for a timecode,
+// frame low nibble first. Each carries one nibble; the last also holds the rate code.
+func EncodeMTCQuarterFrames(totalFrames int, format FPSFormat) ([8][2]byte, error) {
+	var messages [8][2]byte
+	rateCode, err := MTCRateCode(format)
+	if err != nil {
+		return messages, err
+	}
+	tc := FramesToTimecode(ApplyResultMode(totalFrames, format, ResultWrap24h), format)
+
+	values := []int{tc.Frames, tc.Seconds, tc.Minutes, tc.Hours | rateCode<<5}
+	for piece := 0; piece < 8; piece++ {
+		value := values[piece/2]
+		if piece%2 == 1 {
+			value >>= 4
+		}
+		messages[piece] = [2]byte{0xF1, byte(piece<<4 | value&0x0F)}
+	}
+	return messages, nil
+}
+
+// ParseHexBytes reads a hex dump such as "F0 7F 7F 01", "0xF1,0x20" or "f17f"
+func ParseHexBytes(text string) ([]byte, error) {
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "0x", " "), "0X", " ")
+	var digits strings.Builder
+	for _, field := range strings.FieldsFunc(text, func(r rune) bool {
+		return r == ' ' || r == ',' || r == '\n' || r == '\r' || r == '\t' || r == ':' || r == '-'
+	}) {
+		// Single digits in separated dumps are whole bytes ("F1 0 ...")
+		if len(field) == 1 {
+			field = "0" + field
+		}
+		digits.WriteString(field)
+	}
+	if digits.Len() == 0 {
+		return nil, errors.New("no bytes")
+	}
+	data, err := hex.DecodeString(digits.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex bytes: %v", err)
+	}
+	return data, nil
+}
+
+// FormatHexBytes writes bytes as space-separated hex pairs
+func FormatHexBytes(data []byte) string {
+	parts := make([]string, len(data))
+	for i, b := range data {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, " ")
+}
+
+// DecodeMTC finds full-frame messages and complete quarter-frame sequences in a byte dump.
+// Quarter frames count when all eight pieces arrive in order, 0 to 7 or, while rewinding,
+// 7 to 0. Other MIDI bytes are skipped. The hint frame rate keeps NTSC pull-down rates
+// that share an MTC rate code, e.g. 23.976 for rate code 0. Fields that aren't a timecode
+// at the rate are reported as they are, with the reason in Invalid.
+func DecodeMTC(data []byte, hint FPSFormat) ([]MTCTimecode, error) {
+	var result []MTCTimecode
+
+	var pieces [8]int
+	expected := -1 // Next quarter-frame piece of the sequence in progress
+	received := 0
+	reverse := false
+	firstOffset := -1
+
+	for i := 0; i < len(data); i++ {
+		switch {
+		case data[i] == 0xF0 && i+9 < len(data) && data[i+1] == 0x7F && data[i+3] == 0x01 && data[i+4] == 0x01 && data[i+9] == 0xF7:
+			rateCode := int(data[i+5]>>5) & 0x03
+			result = append(result, newMTCTimecode(MTCFullFrame, i, rateCode,
+				int(data[i+5]&0x1F), int(data[i+6]), int(data[i+7]), int(data[i+8]), hint))
+			i += 9
+
+		case data[i] == 0xF1 && i+1 < len(data) && data[i+1] < 0x80:
+			piece := int(data[i+1] >> 4)
+			switch {
+			case piece == expected:
+				received++
+			case piece == 0 || piece == 7:
+				// Piece 0 starts a sequence, piece 7 a reverse one
+				reverse, received, firstOffset = piece == 7, 1, i
+			default:
+				expected = -1
+				i++
+				continue
+			}
+			pieces[piece] = int(data[i+1] & 0x0F)
+			i++
+
+			if received < 8 {
+				expected = piece + 1
+				if reverse {
+					expected = piece - 1
+				}
+				continue
+			}
+			rateCode := (pieces[7] >> 1) & 0x03
+			mtc := newMTCTimecode(MTCQuarterFrames, firstOffset, rateCode,
+				(pieces[7]&0x01)<<4|pieces[6], pieces[5]<<4|pieces[4], pieces[3]<<4|pieces[2], pieces[1]<<4|pieces[0], hint)
+			mtc.Reverse = reverse
+			if reverse && mtc.Invalid == "" {
+				mtc.Position = FramesToTimecode(ApplyResultMode(mtc.Timecode.TotalFrames-2, mtc.Format, ResultWrap24h), mtc.Format)
+			}
+			result = append(result, mtc)
+			expected = -1
+		}
+	}
+
+	if len(result) == 0 {
+		return nil, errors.New("no complete MTC full-frame or quarter-frame messages")
+	}
+	return result, nil
+}
+
+func newMTCTimecode(kind MTCKind, offset, rateCode, hours, minutes, seconds, frames int, hint FPSFormat) MTCTimecode {
+	format := mtcFormat(rateCode, hint)
+	mtc := MTCTimecode{
+		Kind:     kind,
+		Offset:   offset,
+		RateCode: rateCode,
+		Format:   format,
+	}
+
+	// Out-of-range fields are shown as sent rather than normalised to another timecode
+	validation := ValidateTimecode(hours, minutes, seconds, frames, format)
+	if hours > 23 {
+		validation.Valid, validation.Message = false, "hours must be below 24"
+	}
+	if !validation.Valid {
+		mtc.Invalid = validation.Message
+		mtc.Timecode = TimecodeResult{Hours: hours, Minutes: minutes, Seconds: seconds, Frames: frames,
+			Timecode: fmt.Sprintf("%02d:%02d:%02d:%02d", hours, minutes, seconds, frames)}
+		mtc.Position = mtc.Timecode
+		return mtc
+	}
+
+	totalFrames := TimecodeToFrames(hours, minutes, seconds, frames, format)
+	mtc.Timecode = FramesToTimecode(totalFrames, format)
+	mtc.Position = mtc.Timecode
+	if kind == MTCQuarterFrames {
+		mtc.Position = FramesToTimecode(ApplyResultMode(totalFrames+2, format, ResultWrap24h), format)
+	}
+	return mtc
+}
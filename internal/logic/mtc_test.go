@@ -0,0 +1,102 @@
+package logic
+
+import "testing"
+
+// TestEncodeMTC checks full-frame and quarter-frame bytes including the rate code
+func TestEncodeMTC(t *testing.T) {
+	format := GetFPSFormat("29.97 fps (df)")
+	frames, _ := ParseTimecode("01:23:45;29", format)
+
+	full, err := EncodeMTCFullFrame(frames, format, 0x7F)
+	if err != nil {
+		t.Fatalf("EncodeMTCFullFrame failed: %v", err)
+	}
+	// Rate code 2 (drop frame) in bits 5-6 of the hours byte: 0x40 | 1
+	if got := FormatHexBytes(full); got != "F0 7F 7F 01 01 41 17 2D 1D F7" {
+		t.Errorf("full frame = %s", got)
+	}
+
+	quarters, err := EncodeMTCQuarterFrames(frames, format)
+	if err != nil {
+		t.Fatalf("EncodeMTCQuarterFrames failed: %v", err)
+	}
+	var data []byte
+	for _, message := range quarters {
+		data = append(data, message[:]...)
+	}
+	if got := FormatHexBytes(data); got != "F1 0D F1 11 F1 2D F1 32 F1 47 F1 51 F1 61 F1 74" {
+		t.Errorf("quarter frames = %s", got)
+	}
+
+	if _, err := EncodeMTCFullFrame(0, GetFPSFormat("50 fps"), 0x7F); err == nil {
+		t.Error("expected an error for 50 fps")
+	}
+}
+
+// TestDecodeMTC decodes a pasted dump with a full frame, clock bytes and a quarter-frame sequence
+func TestDecodeMTC(t *testing.T) {
+	data, err := ParseHexBytes("0xF0,0x7F,0x7F,0x01,0x01,0x0A,0x00,0x00,0x00,0xF7 F8 F8 f100 f110 f120 f130 f140 f150 f16A f170")
+	if err != nil {
+		t.Fatalf("ParseHexBytes failed: %v", err)
+	}
+
+	// Rate code 0 is 24 fps, or 23.976 when that's the selected rate
+	result, err := DecodeMTC(data, GetFPSFormat("23.976 fps"))
+	if err != nil {
+		t.Fatalf("DecodeMTC failed: %v", err)
+	}
+	if len(result) != 2 {
+		t.Fatalf("got %d timecodes, expected 2", len(result))
+	}
+
+	full := result[0]
+	if full.Kind != MTCFullFrame || full.Timecode.Timecode != "10:00:00:00" || full.Format.Name != "23.976 fps" {
+		t.Errorf("full frame = %s @%s", full.Timecode.Timecode, full.Format.Name)
+	}
+
+	quarter := result[1]
+	if quarter.Kind != MTCQuarterFrames || quarter.Offset != 12 || quarter.Timecode.Timecode != "10:00:00:00" || quarter.Position.Timecode != "10:00:00:02" {
+		t.Errorf("quarter frames = %+v", quarter)
+	}
+
+	if _, err := DecodeMTC([]byte{0xF1, 0x00, 0xF1, 0x10}, GetFPSFormat("25 fps")); err == nil {
+		t.Error("expected an error for an incomplete quarter-frame sequence")
+	}
+}
+
+// TestDecodeMTCInvalid flags fields that aren't a timecode instead of normalising them
+func TestDecodeMTCInvalid(t *testing.T) {
+	// 127 minutes, 30 frames at 25 fps and hour 25
+	data, _ := ParseHexBytes("F0 7F 7F 01 01 21 7F 00 00 F7  F0 7F 7F 01 01 21 00 00 1E F7  F0 7F 7F 01 01 39 00 00 00 F7")
+	result, err := DecodeMTC(data, GetFPSFormat("25 fps"))
+	if err != nil {
+		t.Fatalf("DecodeMTC failed: %v", err)
+	}
+	expected := []string{"01:127:00:00", "01:00:00:30", "25:00:00:00"}
+	if len(result) != len(expected) {
+		t.Fatalf("got %d timecodes, expected %d", len(result), len(expected))
+	}
+	for i, mtc := range result {
+		if mtc.Invalid == "" || mtc.Timecode.Timecode != expected[i] {
+			t.Errorf("message %d = %s (%q), expected %s flagged invalid", i, mtc.Timecode.Timecode, mtc.Invalid, expected[i])
+		}
+	}
+}
+
+// TestDecodeMTCReverse decodes quarter frames sent 7 to 0 while rewinding
+func TestDecodeMTCReverse(t *testing.T) {
+	data, _ := ParseHexBytes("F1 72 F1 6A F1 50 F1 40 F1 30 F1 20 F1 10 F1 00")
+	result, err := DecodeMTC(data, GetFPSFormat("25 fps"))
+	if err != nil {
+		t.Fatalf("DecodeMTC failed: %v", err)
+	}
+	mtc := result[0]
+	if !mtc.Reverse || mtc.Timecode.Timecode != "10:00:00:00" || mtc.Position.Timecode != "09:59:59:23" {
+		t.Errorf("reverse quarter frames = %+v, expected 10:00:00:00, position 09:59:59:23", mtc)
+	}
+
+	// Pieces out of order don't make a timecode
+	if _, err := DecodeMTC([]byte{0xF1, 0x00, 0xF1, 0x20, 0xF1, 0x10, 0xF1, 0x30, 0xF1, 0x40, 0xF1, 0x50, 0xF1, 0x60, 0xF1, 0x70}, GetFPSFormat("25 fps")); err == nil {
+		t.Error("expected an error for pieces out of order")
+	}
+}
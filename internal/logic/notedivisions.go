@@ -0,0 +1,148 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NoteDivision is a note length as a multiple of a quarter note, for GetTempoData
+type NoteDivision struct {
+	Name string // e.g. "1/8", "1/8D", "1/8T" or "1/16 5:4"
+	Mult float64
+}
+
+// Tuplet plays Notes notes in the time of InTimeOf, e.g. 3:2 for triplets
+type Tuplet struct {
+	Notes    int
+	InTimeOf int
+}
+
+func (t Tuplet) String() string {
+	return fmt.Sprintf("%d:%d", t.Notes, t.InTimeOf)
+}
+
+// Validate checks for a positive ratio other than 1:1
+func (t Tuplet) Validate() error {
+	if t.Notes <= 0 || t.InTimeOf <= 0 || t.Notes > 99 || t.InTimeOf > 99 {
+		return errors.New("tuplet notes must be 1 to 99")
+	}
+	if t.Notes == t.InTimeOf {
+		return errors.New("a tuplet must change the note length")
+	}
+	return nil
+}
+
+// ParseTuplets reads a list of tuplet ratios such as "5:4, 7:8 6:4"
+func ParseTuplets(text string) ([]Tuplet, error) {
+	var tuplets []Tuplet
+	fields := strings.FieldsFunc(text, func(r rune) bool { return r == ',' || r == ';' || r == ' ' })
+	for _, field := range fields {
+		notes, inTimeOf, ok := strings.Cut(field, ":")
+		n, err1 := strconv.Atoi(notes)
+		m, err2 := strconv.Atoi(inTimeOf)
+		tuplet := Tuplet{n, m}
+		if !ok || err1 != nil || err2 != nil || tuplet.Validate() != nil {
+			return nil, fmt.Errorf("invalid tuplet %q, use n:m", field)
+		}
+		tuplets = append(tuplets, tuplet)
+	}
+	return tuplets, nil
+}
+
+// Common tuplets: quintuplets and septuplets in the time of four
+var (
+	Triplet    = Tuplet{3, 2}
+	Quintuplet = Tuplet{5, 4}
+	Septuplet  = Tuplet{7, 4}
+)
+
+// MaxNoteValue is the shortest note value NoteDivisions lists, a 1/1024 note
+const MaxNoteValue = 1024
+
+// DivisionOptions selects the variants listed for each note value
+type DivisionOptions struct {
+	Longest      int // Longest note value, a power of two, 1 (or 0) for whole notes
+	Shortest     int // Shortest note value, e.g. 64, at most MaxNoteValue
+	Dotted       bool
+	DoubleDotted bool
+	Triplets     bool
+	Quintuplets  bool
+	Septuplets   bool
+	Tuplets      []Tuplet // Custom tuplets
+}
+
+// DefaultDivisions lists straight, dotted and triplet notes from 1/1 to 1/64
+var DefaultDivisions = DivisionOptions{Longest: 1, Shortest: 64, Dotted: true, Triplets: true}
+
+// Validate checks that the note values are powers of two from 1 to MaxNoteValue,
+// longest first, and that the custom tuplets are valid
+func (o DivisionOptions) Validate() error {
+	longest := max(o.Longest, 1)
+	if o.Longest < 0 || longest&(longest-1) != 0 || longest > MaxNoteValue {
+		return fmt.Errorf("longest note value must be a power of two from 1 to %d", MaxNoteValue)
+	}
+	if o.Shortest < longest || o.Shortest > MaxNoteValue {
+		return fmt.Errorf("shortest note value must be %d to %d", longest, MaxNoteValue)
+	}
+	for _, tuplet := range o.Tuplets {
+		if err := tuplet.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NoteDivisions generates the note lengths for a delay table, longest first;
+// each power-of-two note value is followed by its selected variants.
+// Options that don't pass Validate give no divisions.
+func NoteDivisions(options DivisionOptions) []NoteDivision {
+	if options.Validate() != nil {
+		return nil
+	}
+
+	tuplets := []Tuplet{}
+	if options.Triplets {
+		tuplets = append(tuplets, Triplet)
+	}
+	if options.Quintuplets {
+		tuplets = append(tuplets, Quintuplet)
+	}
+	if options.Septuplets {
+		tuplets = append(tuplets, Septuplet)
+	}
+	for _, tuplet := range options.Tuplets {
+		if !slices.Contains(tuplets, tuplet) {
+			tuplets = append(tuplets, tuplet)
+		}
+	}
+
+	var divisions []NoteDivision
+	for value := max(options.Longest, 1); value <= options.Shortest; value *= 2 {
+		name := fmt.Sprintf("1/%d", value)
+		mult := 4 / float64(value)
+
+		divisions = append(divisions, NoteDivision{name, mult})
+		if options.Dotted {
+			divisions = append(divisions, NoteDivision{name + "D", mult * 1.5})
+		}
+		if options.DoubleDotted {
+			divisions = append(divisions, NoteDivision{name + "DD", mult * 1.75})
+		}
+		for _, tuplet := range tuplets {
+			tupletName := name + " " + tuplet.String()
+			if tuplet == Triplet {
+				tupletName = name + "T"
+			}
+			divisions = append(divisions, NoteDivision{tupletName, mult * float64(tuplet.InTimeOf) / float64(tuplet.Notes)})
+		}
+	}
+	return divisions
+}
+
+// DelaySamples converts a delay in ms to samples
+func DelaySamples(delayMS float64, sampleRate int) float64 {
+	return delayMS * float64(sampleRate) / 1000
+}
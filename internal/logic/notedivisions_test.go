@@ -0,0 +1,97 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNoteDivisionsDefault matches the delay table's straight, dotted and triplet values
+func TestNoteDivisionsDefault(t *testing.T) {
+	divisions := NoteDivisions(DefaultDivisions)
+	if len(divisions) != 21 {
+		t.Fatalf("got %d divisions, expected 21", len(divisions))
+	}
+	expected := map[string]float64{
+		"1/1": 4, "1/1D": 6, "1/1T": 8.0 / 3.0,
+		"1/4": 1, "1/4D": 1.5, "1/4T": 2.0 / 3.0,
+		"1/64": 0.0625, "1/64D": 0.09375, "1/64T": 1.0 / 24.0,
+	}
+	for _, division := range divisions {
+		if mult, ok := expected[division.Name]; ok && math.Abs(division.Mult-mult) > 1e-12 {
+			t.Errorf("%s = %v, expected %v", division.Name, division.Mult, mult)
+		}
+	}
+	if divisions[0].Name != "1/1" || divisions[20].Name != "1/64T" {
+		t.Errorf("order = %s ... %s, expected 1/1 ... 1/64T", divisions[0].Name, divisions[20].Name)
+	}
+}
+
+// TestNoteDivisionsTuplets adds double-dotted notes, quintuplets, septuplets and custom tuplets
+func TestNoteDivisionsTuplets(t *testing.T) {
+	custom, err := ParseTuplets("6:4, 3:2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	divisions := NoteDivisions(DivisionOptions{
+		Longest: 4, Shortest: 16,
+		DoubleDotted: true, Triplets: true, Quintuplets: true, Septuplets: true,
+		Tuplets: custom,
+	})
+
+	// Straight, DD, T, 5:4, 7:4 and 6:4 for each of 1/4, 1/8, 1/16; the custom 3:2 is the triplet
+	if len(divisions) != 18 {
+		t.Fatalf("got %d divisions, expected 18: %v", len(divisions), divisions)
+	}
+	expected := []NoteDivision{
+		{"1/4", 1}, {"1/4DD", 1.75}, {"1/4T", 2.0 / 3.0},
+		{"1/4 5:4", 0.8}, {"1/4 7:4", 4.0 / 7.0}, {"1/4 6:4", 2.0 / 3.0},
+	}
+	for i, division := range expected {
+		if divisions[i].Name != division.Name || math.Abs(divisions[i].Mult-division.Mult) > 1e-12 {
+			t.Errorf("division %d = %+v, expected %+v", i, divisions[i], division)
+		}
+	}
+
+	// A 1/16 quintuplet at 120 BPM: five in the time of a 125 ms sixteenth
+	delay := GetTempoData(120, divisions[15].Mult).DelayMS
+	if divisions[15].Name != "1/16 5:4" || math.Abs(delay-100) > 1e-9 {
+		t.Errorf("%s = %.3f ms, expected 1/16 5:4 = 100 ms", divisions[15].Name, delay)
+	}
+	if samples := DelaySamples(delay, 48000); math.Abs(samples-4800) > 1e-9 {
+		t.Errorf("100 ms at 48 kHz = %v samples, expected 4800", samples)
+	}
+}
+
+// TestDivisionOptionsValidate rejects note values that aren't powers of two or are out of range
+func TestDivisionOptionsValidate(t *testing.T) {
+	invalid := []DivisionOptions{
+		{Longest: 3, Shortest: 64},
+		{Longest: -1, Shortest: 64},
+		{Longest: 1, Shortest: math.MaxInt},
+		{Longest: 1, Shortest: 2048},
+		{Longest: 16, Shortest: 8},
+		{Longest: 1, Shortest: 8, Tuplets: []Tuplet{{4, 4}}},
+	}
+	for _, options := range invalid {
+		if options.Validate() == nil || NoteDivisions(options) != nil {
+			t.Errorf("%+v: expected an error and no divisions", options)
+		}
+	}
+
+	divisions := NoteDivisions(DivisionOptions{Shortest: MaxNoteValue})
+	if options := (DivisionOptions{Shortest: MaxNoteValue}); options.Validate() != nil || len(divisions) != 11 || divisions[10].Name != "1/1024" {
+		t.Errorf("1/1 to 1/1024 = %v", divisions)
+	}
+}
+
+// TestParseTuplets rejects malformed and 1:1 ratios
+func TestParseTuplets(t *testing.T) {
+	for _, text := range []string{"5", "5:x", "4:4", "0:3", "5-4"} {
+		if _, err := ParseTuplets(text); err == nil {
+			t.Errorf("ParseTuplets(%q) succeeded, expected an error", text)
+		}
+	}
+	if tuplets, err := ParseTuplets(" "); err != nil || len(tuplets) != 0 {
+		t.Errorf("empty list = %v, %v", tuplets, err)
+	}
+}
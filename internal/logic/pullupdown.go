@@ -0,0 +1,91 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+)
+
+// SpeedChange is a common source → target frame rate pair for varispeed transfers
+type SpeedChange struct {
+	Name   string
+	Source string // FPSFormat name the material was shot or cut at
+	Target string // FPSFormat name it is played back at
+}
+
+// SpeedChanges lists the usual pull-up, pull-down and PAL speed changes
+var SpeedChanges = []SpeedChange{
+	{"Pull-down 24 → 23.976", "24 fps", "23.976 fps"},
+	{"Pull-up 23.976 → 24", "23.976 fps", "24 fps"},
+	{"PAL speed-up 24 → 25", "24 fps", "25 fps"},
+	{"PAL slow-down 25 → 24", "25 fps", "24 fps"},
+	{"NTSC → PAL 23.976 → 25", "23.976 fps", "25 fps"},
+	{"PAL → NTSC 25 → 23.976", "25 fps", "23.976 fps"},
+	{"Pull-down 30 → 29.97", "30 fps", "29.97 fps"},
+	{"Pull-up 29.97 → 30", "29.97 fps", "30 fps"},
+}
+
+// GetSpeedChange returns the speed change preset with the given name
+func GetSpeedChange(name string) (SpeedChange, bool) {
+	for _, change := range SpeedChanges {
+		if change.Name == name {
+			return change, true
+		}
+	}
+	return SpeedChange{}, false
+}
+
+// SpeedChangeResult holds a duration before and after playing its frames at another rate
+type SpeedChangeResult struct {
+	Frames         int            // Frame count, unchanged by the speed change
+	SourceSeconds  float64        // Real-time duration at the source rate
+	TargetSeconds  float64        // Real-time duration at the target rate
+	SourceTimecode TimecodeResult // Frame count as a timecode at the source rate
+	TargetTimecode TimecodeResult // Frame count as a timecode at the target rate
+	SpeedPercent   float64        // Playback speed relative to the source, 100 = unchanged
+	PitchCents     float64        // Exact pitch change for the varispeed
+	Pitch          TempoChangeResult
+}
+
+// FramesToSeconds returns the real-time duration of a frame count
+func FramesToSeconds(totalFrames int, format FPSFormat) float64 {
+	num, den := format.FrameRate()
+	return float64(totalFrames) * float64(den) / float64(num)
+}
+
+// SecondsToFrames returns the frame count closest to a real-time duration
+func SecondsToFrames(seconds float64, format FPSFormat) int {
+	num, den := format.FrameRate()
+	return int(math.Round(seconds * float64(num) / float64(den)))
+}
+
+// CalculateSpeedChange plays the frames of a source duration at the target rate.
+// Every frame is kept, so the running time and the pitch change by the ratio of the rates,
+// e.g. 24 → 25 runs 4.17% faster and 70.67 cents higher.
+func CalculateSpeedChange(totalFrames int, source, target FPSFormat) SpeedChangeResult {
+	sourceNum, sourceDen := source.FrameRate()
+	targetNum, targetDen := target.FrameRate()
+	sourceRate := float64(sourceNum) / float64(sourceDen)
+	targetRate := float64(targetNum) / float64(targetDen)
+
+	return SpeedChangeResult{
+		Frames:         totalFrames,
+		SourceSeconds:  FramesToSeconds(totalFrames, source),
+		TargetSeconds:  FramesToSeconds(totalFrames, target),
+		SourceTimecode: FramesToTimecode(totalFrames, source),
+		TargetTimecode: FramesToTimecode(totalFrames, target),
+		SpeedPercent:   targetRate / sourceRate * 100.0,
+		PitchCents:     1200.0 * math.Log2(targetRate/sourceRate),
+		Pitch:          CalculateFromNewTempo(sourceRate, targetRate),
+	}
+}
+
+// FormatDuration formats seconds as HH:MM:SS.mmm
+func FormatDuration(seconds float64) string {
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	ms := int64(math.Round(seconds * 1000))
+	return fmt.Sprintf("%s%02d:%02d:%02d.%03d", sign, ms/3600000, ms/60000%60, ms/1000%60, ms%1000)
+}
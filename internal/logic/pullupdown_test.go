@@ -0,0 +1,54 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateSpeedChange checks running time, speed and pitch for the usual transfers
+func TestCalculateSpeedChange(t *testing.T) {
+	testCases := []struct {
+		change         string
+		sourceTC       string
+		targetTC       string
+		targetDuration string
+		speedPercent   float64
+		pitchCents     float64
+	}{
+		// One hour of film runs 3.6 s longer at 23.976
+		{"Pull-down 24 → 23.976", "01:00:00:00", "01:00:00:00", "01:00:03.600", 99.9001, -1.7304},
+		// PAL speed-up: 86400 frames at 25 fps
+		{"PAL speed-up 24 → 25", "01:00:00:00", "00:57:36:00", "00:57:36.000", 104.1667, 70.6724},
+		{"PAL slow-down 25 → 24", "00:57:36:00", "01:00:00:00", "01:00:00.000", 96.0, -70.6724},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.change, func(t *testing.T) {
+			change, ok := GetSpeedChange(tc.change)
+			if !ok {
+				t.Fatalf("unknown speed change %q", tc.change)
+			}
+			source := GetFPSFormat(change.Source)
+			target := GetFPSFormat(change.Target)
+
+			frames, err := ParseTimecode(tc.sourceTC, source)
+			if err != nil {
+				t.Fatalf("ParseTimecode failed: %v", err)
+			}
+			res := CalculateSpeedChange(frames, source, target)
+
+			if res.TargetTimecode.Timecode != tc.targetTC {
+				t.Errorf("target timecode = %s, expected %s", res.TargetTimecode.Timecode, tc.targetTC)
+			}
+			if got := FormatDuration(res.TargetSeconds); got != tc.targetDuration {
+				t.Errorf("target duration = %s, expected %s", got, tc.targetDuration)
+			}
+			if math.Abs(res.SpeedPercent-tc.speedPercent) > 0.0001 {
+				t.Errorf("speed = %.4f%%, expected %.4f%%", res.SpeedPercent, tc.speedPercent)
+			}
+			if math.Abs(res.PitchCents-tc.pitchCents) > 0.0001 {
+				t.Errorf("pitch = %.4f cents, expected %.4f", res.PitchCents, tc.pitchCents)
+			}
+		})
+	}
+}
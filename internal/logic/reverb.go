@@ -0,0 +1,42 @@
+package logic
+
+import "errors"
+
+// ReverbPreDelayDivisions are the note values offered as reverb pre-delay, 1/16 down to 1/128
+var ReverbPreDelayDivisions = NoteDivisions(DivisionOptions{Longest: 16, Shortest: 128, Dotted: true, Triplets: true})
+
+// ReverbTailDivisions are the note values a reverb tail can end on, four bars of 4/4 down to 1/4
+var ReverbTailDivisions = append([]NoteDivision{{"4 bars", 16}, {"2 bars", 8}},
+	NoteDivisions(DivisionOptions{Longest: 1, Shortest: 4, Dotted: true, Triplets: true})...)
+
+// ReverbResult holds a tempo-synced reverb setting
+type ReverbResult struct {
+	PreDelayMS      float64
+	PreDelaySamples float64
+	DecayMS         float64 // Decay time (RT60) that lets the tail end on the note
+	DecaySamples    float64
+	TailMS          float64 // Pre-delay and decay together, the length of the note
+	TailSamples     float64
+}
+
+// CalculateReverb fits a reverb to a tempo: the pre-delay is one note value and the decay
+// fills the rest of a longer one, so the tail dies away as that note ends. Note values are
+// multipliers of a quarter note as in GetTempoData.
+func CalculateReverb(bpm, preDelayMult, tailMult float64, sampleRate int) (ReverbResult, error) {
+	if bpm <= 0 {
+		return ReverbResult{}, errors.New("tempo must be positive")
+	}
+	if preDelayMult < 0 || tailMult <= preDelayMult {
+		return ReverbResult{}, errors.New("the tail must be longer than the pre-delay")
+	}
+
+	r := ReverbResult{
+		PreDelayMS: GetTempoData(bpm, preDelayMult).DelayMS,
+		TailMS:     GetTempoData(bpm, tailMult).DelayMS,
+	}
+	r.DecayMS = r.TailMS - r.PreDelayMS
+	r.PreDelaySamples = DelaySamples(r.PreDelayMS, sampleRate)
+	r.DecaySamples = DelaySamples(r.DecayMS, sampleRate)
+	r.TailSamples = DelaySamples(r.TailMS, sampleRate)
+	return r, nil
+}
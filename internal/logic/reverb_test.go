@@ -0,0 +1,51 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateReverb ends a reverb with a 1/64 pre-delay on a half note at 120 BPM
+func TestCalculateReverb(t *testing.T) {
+	r, err := CalculateReverb(120, 1.0/16, 2, 48000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []float64{r.PreDelayMS, r.DecayMS, r.TailMS, r.PreDelaySamples, r.DecaySamples, r.TailSamples}
+	expected := []float64{31.25, 968.75, 1000, 1500, 46500, 48000}
+	for i := range got {
+		if math.Abs(got[i]-expected[i]) > 1e-9 {
+			t.Errorf("got %v, expected %v", got, expected)
+			break
+		}
+	}
+
+	// Without pre-delay the decay is the whole note
+	if r, _ := CalculateReverb(90, 0, 4, 44100); math.Abs(r.DecayMS-8000.0/3) > 1e-9 || r.PreDelayMS != 0 {
+		t.Errorf("no pre-delay: got %.3f ms decay after %.3f ms, expected 2666.667 ms after 0", r.DecayMS, r.PreDelayMS)
+	}
+
+	if _, err := CalculateReverb(120, 0.25, 0.25, 48000); err == nil {
+		t.Error("tail as long as the pre-delay: expected an error")
+	}
+	if _, err := CalculateReverb(0, 0.25, 1, 48000); err == nil {
+		t.Error("0 BPM: expected an error")
+	}
+}
+
+// TestReverbDivisions checks the pre-delays stay short and every tail outlasts them
+func TestReverbDivisions(t *testing.T) {
+	for _, note := range ReverbPreDelayDivisions {
+		if note.Mult > 0.375 || note.Mult < 1.0/32*2/3 {
+			t.Errorf("pre-delay %s (%g) outside 1/128T to 1/16D", note.Name, note.Mult)
+		}
+	}
+	if ReverbTailDivisions[0].Name != "4 bars" || ReverbTailDivisions[0].Mult != 16 {
+		t.Errorf("first tail %v, expected 4 bars", ReverbTailDivisions[0])
+	}
+	for _, note := range ReverbTailDivisions {
+		if note.Mult <= 0.375 {
+			t.Errorf("tail %s (%g) no longer than the longest pre-delay", note.Name, note.Mult)
+		}
+	}
+}
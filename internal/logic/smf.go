@@ -0,0 +1,244 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+)
+
+// General MIDI percussion notes and channel used for the click track
+const (
+	MIDIClickChannel    = 9  // Channel 10
+	MIDIClickAccentNote = 76 // Hi wood block
+	MIDIClickBeatNote   = 77 // Low wood block
+)
+
+// midiClickVelocities are the note velocities for each kind of click
+var midiClickVelocities = [...]byte{
+	ClickDownbeat:    127,
+	ClickBeat:        100,
+	ClickSubdivision: 70,
+}
+
+// MIDIFileMaxBars is the longest MIDI file WriteMIDIFile writes
+const MIDIFileMaxBars = 9999
+
+// midiRampSteps is how many tempo events a ramp writes per quarter note
+const midiRampSteps = 4
+
+// MIDIFileOptions describes a type 1 Standard MIDI File with a tempo track and an optional click track
+type MIDIFileOptions struct {
+	Map              *TempoMap // Tempo and meter changes; ramps are written as steps
+	Bars             int       // Length from bar 1
+	SMPTEOffset      bool      // Write the start timecode so the file lines up in the session
+	Format           FPSFormat // Frame rate of the SMPTE offset
+	StartFrames      int       // Timecode of bar 1 in frames
+	Click            bool      // Add a click note track
+	ClickSubdivision int       // Clicks per beat
+}
+
+// midiEvent is an event at an absolute tick
+type midiEvent struct {
+	tick int
+	data []byte
+}
+
+// midiTrack collects events and writes them as an MTrk chunk
+type midiTrack struct {
+	events []midiEvent
+}
+
+func (t *midiTrack) add(tick int, data ...byte) {
+	t.events = append(t.events, midiEvent{tick, data})
+}
+
+func (t *midiTrack) meta(tick int, kind byte, data ...byte) {
+	t.add(tick, append([]byte{0xFF, kind, byte(len(data))}, data...)...)
+}
+
+// write sorts the events by tick, keeping the order of events on the same tick, and ends the track at end
+func (t *midiTrack) write(w io.Writer, end int) error {
+	sort.SliceStable(t.events, func(i, j int) bool { return t.events[i].tick < t.events[j].tick })
+
+	var chunk bytes.Buffer
+	tick := 0
+	for _, event := range t.events {
+		chunk.Write(AppendVLQ(nil, uint32(event.tick-tick)))
+		chunk.Write(event.data)
+		tick = event.tick
+	}
+	chunk.Write(AppendVLQ(nil, uint32(max(end-tick, 0))))
+	chunk.Write([]byte{0xFF, 0x2F, 0x00}) // End of track
+
+	if _, err := w.Write([]byte("MTrk")); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.BigEndian, uint32(chunk.Len())); err != nil {
+		return err
+	}
+	_, err := w.Write(chunk.Bytes())
+	return err
+}
+
+// AppendVLQ appends a MIDI variable-length quantity: 7 bits per byte, most significant first
+func AppendVLQ(b []byte, value uint32) []byte {
+	var groups [5]byte
+	n := 0
+	for {
+		groups[n] = byte(value & 0x7F)
+		n++
+		value >>= 7
+		if value == 0 {
+			break
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		if i > 0 {
+			b = append(b, groups[i]|0x80)
+		} else {
+			b = append(b, groups[i])
+		}
+	}
+	return b
+}
+
+// smpteRateCode returns the SMPTE offset frame rate bits: 24, 25, 30 drop frame or 30 fps.
+// The NTSC film and video rates share the code of their nominal rate.
+func smpteRateCode(format FPSFormat) (byte, error) {
+	switch {
+	case format.NominalRate() == 24:
+		return 0, nil
+	case format.NominalRate() == 25:
+		return 1, nil
+	case format.NominalRate() == 30 && format.DropFrame:
+		return 2, nil
+	case format.NominalRate() == 30:
+		return 3, nil
+	}
+	return 0, fmt.Errorf("a MIDI file's SMPTE offset can't be %s, only 24, 25 or 30 fps", format.Name)
+}
+
+// WriteMIDIFile writes a type 1 Standard MIDI File: a tempo track with the time signatures,
+// tempos and SMPTE offset, and optionally a click track with accented downbeats
+func WriteMIDIFile(w io.Writer, opts MIDIFileOptions) error {
+	m := opts.Map
+	if err := m.Validate(); err != nil {
+		return err
+	}
+	if m.PPQ > 0x7FFF {
+		return errors.New("a MIDI file's PPQ must be at most 32767")
+	}
+	if opts.Bars <= 0 || opts.Bars > MIDIFileMaxBars {
+		return fmt.Errorf("bars must be 1 to %d", MIDIFileMaxBars)
+	}
+	barTicks := func(bar int) int {
+		ticks, _ := m.BBTToTicks(BBT{Bar: bar, Beat: 1})
+		return ticks
+	}
+	end := barTicks(opts.Bars + 1)
+
+	tempo := &midiTrack{}
+	tempo.meta(0, 0x03, []byte("Tempo")...)
+	if opts.SMPTEOffset {
+		code, err := smpteRateCode(opts.Format)
+		if err != nil {
+			return err
+		}
+		tc := FramesToTimecode(ApplyResultMode(opts.StartFrames, opts.Format, ResultWrap24h), opts.Format)
+		tempo.meta(0, 0x54, code<<5|byte(tc.Hours), byte(tc.Minutes), byte(tc.Seconds), byte(tc.Frames), 0)
+	}
+
+	for i, segment := range m.Segments {
+		start := barTicks(segment.Bar)
+		if start >= end {
+			break
+		}
+		denominator := byte(math.Log2(float64(segment.Signature.Denominator)))
+		clocksPerBeat := byte(96 / segment.Signature.Denominator)
+		tempo.meta(start, 0x58, byte(segment.Signature.Numerator), denominator, clocksPerBeat, 8)
+
+		// Ramps step at every sixteenth, each step at the average tempo across it so the time adds up
+		stop := end
+		if i+1 < len(m.Segments) {
+			stop = min(barTicks(m.Segments[i+1].Bar), end)
+		}
+		step := stop - start
+		if segment.Curve != TempoConstant {
+			step = max(m.PPQ/midiRampSteps, 1)
+		}
+		for tick := start; tick < stop; tick += step {
+			next := min(tick+step, stop)
+			seconds := m.TicksToSeconds(float64(next)) - m.TicksToSeconds(float64(tick))
+			microseconds := math.Round(seconds / float64(next-tick) * float64(m.PPQ) * 1e6)
+			if microseconds > 0xFFFFFF {
+				return fmt.Errorf("bar %d: tempo too slow for a MIDI file", segment.Bar)
+			}
+			us := uint32(microseconds)
+			tempo.meta(tick, 0x51, byte(us>>16), byte(us>>8), byte(us))
+		}
+	}
+
+	tracks := []*midiTrack{tempo}
+	if opts.Click {
+		click, err := midiClickTrack(opts, barTicks)
+		if err != nil {
+			return err
+		}
+		tracks = append(tracks, click)
+	}
+
+	header := []interface{}{
+		[4]byte{'M', 'T', 'h', 'd'},
+		uint32(6),
+		uint16(1),           // Type 1: simultaneous tracks
+		uint16(len(tracks)), // Tracks
+		uint16(m.PPQ),       // Ticks per quarter note
+	}
+	for _, field := range header {
+		if err := binary.Write(w, binary.BigEndian, field); err != nil {
+			return err
+		}
+	}
+	for _, track := range tracks {
+		if err := track.write(w, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// midiClickTrack writes a note for every beat and subdivision, half a click long
+func midiClickTrack(opts MIDIFileOptions, barTicks func(int) int) (*midiTrack, error) {
+	subdivision := opts.ClickSubdivision
+	if subdivision <= 0 || subdivision > 16 {
+		return nil, errors.New("subdivision must be 1 to 16 clicks per beat")
+	}
+
+	click := &midiTrack{}
+	click.meta(0, 0x03, []byte("Click")...)
+	for bar := 1; bar <= opts.Bars; bar++ {
+		signature := opts.Map.SignatureAt(bar)
+		beatTicks := opts.Map.PPQ * 4 / signature.Denominator
+		length := max(beatTicks/subdivision/2, 1)
+		for beat := 0; beat < signature.Numerator; beat++ {
+			for sub := 0; sub < subdivision; sub++ {
+				tick := barTicks(bar) + beat*beatTicks + sub*beatTicks/subdivision
+				kind, note := ClickSubdivision, byte(MIDIClickBeatNote)
+				switch {
+				case sub > 0:
+				case beat == 0:
+					kind, note = ClickDownbeat, MIDIClickAccentNote
+				default:
+					kind = ClickBeat
+				}
+				click.add(tick, 0x90|MIDIClickChannel, note, midiClickVelocities[kind])
+				click.add(tick+length, 0x80|MIDIClickChannel, note, 0)
+			}
+		}
+	}
+	return click, nil
+}
@@ -0,0 +1,198 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// smfTestEvent is an event read back from a track
+type smfTestEvent struct {
+	tick int
+	data []byte
+}
+
+// readSMF splits a file into its header values and the events of each track
+func readSMF(t *testing.T, data []byte) (format, division int, tracks [][]smfTestEvent) {
+	t.Helper()
+	if string(data[:4]) != "MThd" || binary.BigEndian.Uint32(data[4:]) != 6 {
+		t.Fatalf("bad header %q", data[:8])
+	}
+	format = int(binary.BigEndian.Uint16(data[8:]))
+	count := int(binary.BigEndian.Uint16(data[10:]))
+	division = int(binary.BigEndian.Uint16(data[12:]))
+	data = data[14:]
+
+	for i := 0; i < count; i++ {
+		if string(data[:4]) != "MTrk" {
+			t.Fatalf("track %d: bad chunk %q", i, data[:4])
+		}
+		length := int(binary.BigEndian.Uint32(data[4:]))
+		chunk := data[8 : 8+length]
+		data = data[8+length:]
+
+		var events []smfTestEvent
+		tick := 0
+		for len(chunk) > 0 {
+			delta := 0
+			for {
+				b := chunk[0]
+				chunk = chunk[1:]
+				delta = delta<<7 | int(b&0x7F)
+				if b&0x80 == 0 {
+					break
+				}
+			}
+			tick += delta
+			size := 3
+			if chunk[0] == 0xFF {
+				size = 3 + int(chunk[2])
+			}
+			events = append(events, smfTestEvent{tick, chunk[:size]})
+			chunk = chunk[size:]
+		}
+		tracks = append(tracks, events)
+	}
+	if len(data) != 0 {
+		t.Fatalf("%d bytes after the last track", len(data))
+	}
+	return format, division, tracks
+}
+
+// metaEvents returns the events of one meta kind
+func metaEvents(events []smfTestEvent, kind byte) []smfTestEvent {
+	var found []smfTestEvent
+	for _, event := range events {
+		if event.data[0] == 0xFF && event.data[1] == kind {
+			found = append(found, event)
+		}
+	}
+	return found
+}
+
+// TestAppendVLQ encodes variable-length quantities
+func TestAppendVLQ(t *testing.T) {
+	tests := map[uint32][]byte{
+		0:          {0x00},
+		0x7F:       {0x7F},
+		0x80:       {0x81, 0x00},
+		0x3FFF:     {0xFF, 0x7F},
+		0x0FFFFFFF: {0xFF, 0xFF, 0xFF, 0x7F},
+	}
+	for value, expected := range tests {
+		if got := AppendVLQ(nil, value); !bytes.Equal(got, expected) {
+			t.Errorf("AppendVLQ(%#x) = % X, expected % X", value, got, expected)
+		}
+	}
+}
+
+// TestWriteMIDIFile writes the tempo, meter, SMPTE offset and click track
+func TestWriteMIDIFile(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	grid := MusicalGrid{BPM: 120, Signature: TimeSignature{7, 8}, PPQ: 480}
+	var buf bytes.Buffer
+	err := WriteMIDIFile(&buf, MIDIFileOptions{
+		Map:              grid.TempoMap(),
+		Bars:             2,
+		SMPTEOffset:      true,
+		Format:           format,
+		StartFrames:      TimecodeToFrames(1, 0, 0, 0, format),
+		Click:            true,
+		ClickSubdivision: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileFormat, division, tracks := readSMF(t, buf.Bytes())
+	if fileFormat != 1 || division != 480 || len(tracks) != 2 {
+		t.Fatalf("format %d, division %d, %d tracks, expected type 1, 480 PPQ, 2 tracks", fileFormat, division, len(tracks))
+	}
+
+	expected := map[byte][]byte{
+		0x54: {0xFF, 0x54, 0x05, 0x21, 0x00, 0x00, 0x00, 0x00}, // 01:00:00:00 at 25 fps
+		0x58: {0xFF, 0x58, 0x04, 0x07, 0x03, 0x0C, 0x08},       // 7/8, 12 clocks per eighth
+		0x51: {0xFF, 0x51, 0x03, 0x07, 0xA1, 0x20},             // 500000 µs per quarter
+	}
+	for kind, data := range expected {
+		events := metaEvents(tracks[0], kind)
+		if len(events) != 1 || events[0].tick != 0 || !bytes.Equal(events[0].data, data) {
+			t.Errorf("meta %#x = %v, expected % X at tick 0", kind, events, data)
+		}
+	}
+
+	// 14 eighth-note clicks, accented on each bar line, ending at bar 3
+	var ons []smfTestEvent
+	for _, event := range tracks[1] {
+		if event.data[0] == 0x99 {
+			ons = append(ons, event)
+		}
+	}
+	if len(ons) != 14 {
+		t.Fatalf("got %d clicks, expected 14", len(ons))
+	}
+	if ons[0].data[1] != MIDIClickAccentNote || ons[1].data[1] != MIDIClickBeatNote || ons[7].data[1] != MIDIClickAccentNote {
+		t.Errorf("click notes % X, % X, % X, expected accents on bar lines", ons[0].data, ons[1].data, ons[7].data)
+	}
+	if ons[1].tick != 240 || ons[7].tick != 7*240 {
+		t.Errorf("click ticks %d and %d, expected 240 and 1680", ons[1].tick, ons[7].tick)
+	}
+	last := tracks[1][len(tracks[1])-1]
+	if !bytes.Equal(last.data, []byte{0xFF, 0x2F, 0x00}) || last.tick != 14*240 {
+		t.Errorf("track ends with % X at %d, expected end of track at 3360", last.data, last.tick)
+	}
+}
+
+// TestWriteMIDIFileRamp steps through a ramp so the file's time matches the tempo map
+func TestWriteMIDIFileRamp(t *testing.T) {
+	tempos, err := ParseTempoMap(strings.NewReader("ppq 960\n1 90>120 linear\n3 120 3/4"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := WriteMIDIFile(&buf, MIDIFileOptions{Map: tempos, Bars: 4}); err != nil {
+		t.Fatal(err)
+	}
+	_, _, tracks := readSMF(t, buf.Bytes())
+	if len(tracks) != 1 {
+		t.Fatalf("got %d tracks, expected only the tempo track", len(tracks))
+	}
+
+	// 32 sixteenth steps through the ramp, then one tempo for the rest
+	events := metaEvents(tracks[0], 0x51)
+	if len(events) != 33 {
+		t.Fatalf("got %d tempo events, expected 33", len(events))
+	}
+	if signatures := metaEvents(tracks[0], 0x58); len(signatures) != 2 || signatures[1].tick != 2*3840 {
+		t.Errorf("time signatures %v, expected 4/4 and 3/4 at bar 3", signatures)
+	}
+
+	// Play the tempo events back to the end of bar 4
+	end := 2*3840 + 2*2880
+	seconds := 0.0
+	for i, event := range events {
+		next := end
+		if i+1 < len(events) {
+			next = events[i+1].tick
+		}
+		us := int(event.data[3])<<16 | int(event.data[4])<<8 | int(event.data[5])
+		seconds += float64(next-event.tick) / 960 * float64(us) / 1e6
+	}
+	if expected := tempos.TicksToSeconds(float64(end)); math.Abs(seconds-expected) > 1e-4 {
+		t.Errorf("file plays %.6f s, expected %.6f s", seconds, expected)
+	}
+}
+
+// TestWriteMIDIFileErrors rejects SMPTE offsets at rates a MIDI file can't hold
+func TestWriteMIDIFileErrors(t *testing.T) {
+	tempos := MusicalGrid{BPM: 120, Signature: TimeSignature{4, 4}, PPQ: 960}.TempoMap()
+	var buf bytes.Buffer
+	if err := WriteMIDIFile(&buf, MIDIFileOptions{Map: tempos, Bars: 1, SMPTEOffset: true, Format: GetFPSFormat("50 fps")}); err == nil {
+		t.Error("50 fps SMPTE offset: expected an error")
+	}
+	if err := WriteMIDIFile(&buf, MIDIFileOptions{Map: tempos, Bars: 0}); err == nil {
+		t.Error("no bars: expected an error")
+	}
+}
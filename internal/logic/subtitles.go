@@ -0,0 +1,298 @@
+package logic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// SubtitleFormat identifies a subtitle file format
+type SubtitleFormat int
+
+const (
+	SubtitleSRT SubtitleFormat = iota
+	SubtitleWebVTT
+)
+
+// SubtitleFormatNames lists the subtitle formats in SubtitleFormat order
+var SubtitleFormatNames = []string{"SRT", "WebVTT"}
+
+// Extension returns the file extension for the format
+func (f SubtitleFormat) Extension() string {
+	if f == SubtitleWebVTT {
+		return ".vtt"
+	}
+	return ".srt"
+}
+
+// SubtitleCue is one timed subtitle, with times in milliseconds
+type SubtitleCue struct {
+	ID       string // SRT counter or WebVTT cue identifier
+	Start    int64
+	End      int64
+	Settings string // WebVTT cue settings after the end time (e.g. "line:0 align:start")
+	Text     []string
+	Blocks   []string // WebVTT NOTE, STYLE and REGION blocks between the previous cue and this one
+}
+
+// SubtitleFile holds a parsed SRT or WebVTT file
+type SubtitleFile struct {
+	Format  SubtitleFormat
+	Header  []string // WebVTT header and NOTE/STYLE/REGION blocks, kept as written
+	Cues    []SubtitleCue
+	Trailer []string // WebVTT blocks after the last cue
+}
+
+// SubtitleRetime describes how cue times are moved.
+// Rescaling keeps each cue on the same frame when the video is played at the
+// target rate, e.g. 25 → 23.976 for a PAL speed-up undone. The offset is a
+// timecode at the target rate and may be negative.
+type SubtitleRetime struct {
+	Source       FPSFormat
+	Target       FPSFormat
+	Rescale      bool
+	OffsetFrames int
+	SnapToFrames bool
+}
+
+// SubtitleRetimeResult counts what a retime did to the cues
+type SubtitleRetimeResult struct {
+	Cues    int // Cues written
+	Dropped int // Cues that ended before zero after a negative offset
+}
+
+// ParseSubtitles reads an SRT or WebVTT file; WebVTT is detected from its header
+func ParseSubtitles(r io.Reader) (*SubtitleFile, error) {
+	scanner := bufio.NewScanner(r)
+	var lines []string
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) > 0 {
+		lines[0] = strings.TrimPrefix(lines[0], "\ufeff")
+	}
+
+	file := &SubtitleFile{Format: SubtitleSRT}
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "WEBVTT") {
+		file.Format = SubtitleWebVTT
+	}
+
+	// Split into blank-line separated blocks
+	var blocks [][]string
+	var block []string
+	for _, line := range append(lines, "") {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+
+	var pending []string // Non-cue blocks waiting for the next cue
+	for _, block := range blocks {
+		timingLine := -1
+		for j, line := range block {
+			if strings.Contains(line, "-->") {
+				timingLine = j
+				break
+			}
+		}
+
+		// Header, NOTE, STYLE and REGION blocks have no timing line. Blocks before
+		// the first cue are the header, later ones stay in front of the next cue.
+		if timingLine < 0 {
+			if file.Format != SubtitleWebVTT {
+				return nil, fmt.Errorf("cue %d: missing timing line", len(file.Cues)+1)
+			}
+			if len(file.Cues) == 0 {
+				file.Header = append(file.Header, strings.Join(block, "\n"))
+			} else {
+				pending = append(pending, strings.Join(block, "\n"))
+			}
+			continue
+		}
+
+		cue := SubtitleCue{ID: strings.Join(block[:timingLine], " "), Blocks: pending}
+		pending = nil
+		parts := strings.SplitN(block[timingLine], "-->", 2)
+		start, err := parseSubtitleTime(parts[0])
+		if err != nil {
+			return nil, fmt.Errorf("cue %d: %v", len(file.Cues)+1, err)
+		}
+		endFields := strings.Fields(parts[1])
+		if len(endFields) == 0 {
+			return nil, fmt.Errorf("cue %d: missing end time", len(file.Cues)+1)
+		}
+		end, err := parseSubtitleTime(endFields[0])
+		if err != nil {
+			return nil, fmt.Errorf("cue %d: %v", len(file.Cues)+1, err)
+		}
+		cue.Start, cue.End = start, end
+		cue.Settings = strings.Join(endFields[1:], " ")
+		cue.Text = block[timingLine+1:]
+
+		file.Cues = append(file.Cues, cue)
+	}
+
+	if len(file.Cues) == 0 {
+		return nil, errors.New("no subtitle cues found")
+	}
+	file.Trailer = pending
+	return file, nil
+}
+
+// parseSubtitleTime reads "HH:MM:SS,mmm" (SRT) or "HH:MM:SS.mmm" / "MM:SS.mmm" (WebVTT)
+func parseSubtitleTime(text string) (int64, error) {
+	text = strings.TrimSpace(text)
+	fields := strings.Split(strings.Replace(text, ",", ".", 1), ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+
+	secondsParts := strings.SplitN(fields[len(fields)-1], ".", 2)
+	if len(secondsParts) != 2 {
+		return 0, fmt.Errorf("invalid time %q", text)
+	}
+	values := append(fields[:len(fields)-1], secondsParts...)
+
+	var numbers []int64
+	for _, value := range values {
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid time %q", text)
+		}
+		numbers = append(numbers, n)
+	}
+	if len(numbers) == 3 {
+		numbers = append([]int64{0}, numbers...)
+	}
+
+	// Milliseconds may be written with fewer or more than three digits
+	ms := numbers[3]
+	for digits := len(secondsParts[1]); digits < 3; digits++ {
+		ms *= 10
+	}
+	for digits := len(secondsParts[1]); digits > 3; digits-- {
+		ms /= 10
+	}
+	return ((numbers[0]*60+numbers[1])*60+numbers[2])*1000 + ms, nil
+}
+
+// formatSubtitleTime writes a time as "HH:MM:SS,mmm" (SRT) or "HH:MM:SS.mmm" (WebVTT)
+func formatSubtitleTime(ms int64, format SubtitleFormat) string {
+	separator := ","
+	if format == SubtitleWebVTT {
+		separator = "."
+	}
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, separator, ms%1000)
+}
+
+// Retime rescales, offsets and frame-snaps all cues in place
+func (s *SubtitleFile) Retime(retime SubtitleRetime) SubtitleRetimeResult {
+	sourceNum, sourceDen := retime.Source.FrameRate()
+	targetNum, targetDen := retime.Target.FrameRate()
+	offsetMS := FramesToSeconds(retime.OffsetFrames, retime.Target) * 1000
+
+	move := func(ms int64) float64 {
+		t := float64(ms)
+		if retime.Rescale {
+			// Same frame at the target rate: t × source fps / target fps
+			t = t * float64(sourceNum*targetDen) / float64(sourceDen*targetNum)
+		}
+		return t + offsetMS
+	}
+	snap := func(t float64) int64 {
+		if retime.SnapToFrames {
+			frame := math.Round(t / 1000 * float64(targetNum) / float64(targetDen))
+			t = frame * 1000 * float64(targetDen) / float64(targetNum)
+		}
+		return int64(math.Round(t))
+	}
+
+	result := SubtitleRetimeResult{}
+	cues := s.Cues[:0]
+	var blocks []string // Blocks of dropped cues, kept for the next cue
+	for _, cue := range s.Cues {
+		start, end := snap(move(cue.Start)), snap(move(cue.End))
+		if end <= 0 {
+			result.Dropped++
+			blocks = append(blocks, cue.Blocks...)
+			continue
+		}
+		cue.Blocks = append(blocks, cue.Blocks...)
+		blocks = nil
+		if start < 0 {
+			start = 0
+		}
+		// Snapping can collapse very short cues, keep at least one frame
+		if retime.SnapToFrames && end <= start {
+			end = snap(float64(start) + 1000*float64(targetDen)/float64(targetNum))
+		}
+		cue.Start, cue.End = start, end
+		cues = append(cues, cue)
+	}
+	s.Cues = cues
+	s.Trailer = append(blocks, s.Trailer...)
+	result.Cues = len(cues)
+	return result
+}
+
+// WriteSubtitles writes the cues as SRT or WebVTT.
+// SRT cues are renumbered; WebVTT keeps cue identifiers, settings and the
+// NOTE, STYLE and REGION blocks in their place between the cues.
+func WriteSubtitles(w io.Writer, s *SubtitleFile, format SubtitleFormat) error {
+	bw := bufio.NewWriter(w)
+
+	if format == SubtitleWebVTT {
+		if len(s.Header) > 0 && s.Format == SubtitleWebVTT {
+			fmt.Fprintf(bw, "%s\n\n", strings.Join(s.Header, "\n\n"))
+		} else {
+			fmt.Fprint(bw, "WEBVTT\n\n")
+		}
+	}
+
+	keepBlocks := format == SubtitleWebVTT && s.Format == SubtitleWebVTT
+	for i, cue := range s.Cues {
+		if keepBlocks {
+			for _, block := range cue.Blocks {
+				fmt.Fprintf(bw, "%s\n\n", block)
+			}
+		}
+		switch {
+		case format == SubtitleSRT:
+			fmt.Fprintf(bw, "%d\n", i+1)
+		case cue.ID != "" && s.Format == SubtitleWebVTT:
+			fmt.Fprintf(bw, "%s\n", cue.ID)
+		}
+
+		fmt.Fprintf(bw, "%s --> %s", formatSubtitleTime(cue.Start, format), formatSubtitleTime(cue.End, format))
+		if format == SubtitleWebVTT && cue.Settings != "" {
+			fmt.Fprintf(bw, " %s", cue.Settings)
+		}
+		fmt.Fprint(bw, "\n")
+
+		for _, line := range cue.Text {
+			fmt.Fprintf(bw, "%s\n", line)
+		}
+		if i < len(s.Cues)-1 {
+			fmt.Fprint(bw, "\n")
+		}
+	}
+	if keepBlocks {
+		for _, block := range s.Trailer {
+			fmt.Fprintf(bw, "\n%s\n", block)
+		}
+	}
+
+	return bw.Flush()
+}
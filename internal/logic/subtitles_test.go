@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const testSRT = "1\r\n00:00:01,000 --> 00:00:03,500\r\nHello\r\n\r\n2\r\n00:01:00,040 --> 00:01:02,000\r\n<i>Second</i>\r\nline two\r\n"
+
+// TestSubtitleRoundTrip checks that SRT and WebVTT are read and written unchanged
+func TestSubtitleRoundTrip(t *testing.T) {
+	vtt := "WEBVTT\n\nNOTE made by hand\n\nintro\n00:01.000 --> 00:03.500 align:start\nHello\n"
+	// Notes and styles between and after the cues stay where they were
+	vttNotes := "WEBVTT\n\n00:00:01.000 --> 00:00:02.000\nOne\n\nNOTE check this line\n\nSTYLE\n::cue { color: yellow }\n\n" +
+		"00:00:03.000 --> 00:00:04.000\nTwo\n\nNOTE end of reel\n"
+
+	for _, input := range []string{testSRT, vtt, vttNotes} {
+		file, err := ParseSubtitles(strings.NewReader(input))
+		if err != nil {
+			t.Fatalf("ParseSubtitles failed: %v", err)
+		}
+		var out bytes.Buffer
+		if err := WriteSubtitles(&out, file, file.Format); err != nil {
+			t.Fatalf("WriteSubtitles failed: %v", err)
+		}
+		expected := strings.ReplaceAll(input, "\r\n", "\n")
+		if file.Format == SubtitleWebVTT {
+			// Short MM:SS.mmm times are written in full
+			expected = strings.ReplaceAll(expected, "00:01.000 --> 00:03.500", "00:00:01.000 --> 00:00:03.500")
+		}
+		if out.String() != expected {
+			t.Errorf("round trip =\n%s\nexpected\n%s", out.String(), expected)
+		}
+	}
+}
+
+// TestSubtitleRetime checks rescaling, offsets and frame snapping
+func TestSubtitleRetime(t *testing.T) {
+	file, err := ParseSubtitles(strings.NewReader(testSRT))
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+
+	// 25 → 24 keeps every cue on its frame: times grow by 25/24
+	target := GetFPSFormat("24 fps")
+	result := file.Retime(SubtitleRetime{
+		Source:       GetFPSFormat("25 fps"),
+		Target:       target,
+		Rescale:      true,
+		OffsetFrames: TimecodeToFrames(1, 0, 0, 0, target),
+		SnapToFrames: true,
+	})
+	if result.Cues != 2 || result.Dropped != 0 {
+		t.Errorf("result = %+v, expected 2 cues", result)
+	}
+
+	var out bytes.Buffer
+	if err := WriteSubtitles(&out, file, SubtitleWebVTT); err != nil {
+		t.Fatalf("WriteSubtitles failed: %v", err)
+	}
+	// Cue 1: 1.000 s → 1.041667 s is frame 25 at 24 fps, 3.500 s → 3.645833 s snaps to frame 88 (3.666667 s)
+	// Cue 2: 60.040 s → 62.541667 s is frame 1501, 62.000 s → 64.583333 s is frame 1550
+	expected := "WEBVTT\n\n" +
+		"01:00:01.042 --> 01:00:03.667\nHello\n\n" +
+		"01:01:02.542 --> 01:01:04.583\n<i>Second</i>\nline two\n"
+	if out.String() != expected {
+		t.Errorf("retimed =\n%s\nexpected\n%s", out.String(), expected)
+	}
+
+	// A negative offset past the first cue drops it
+	file, _ = ParseSubtitles(strings.NewReader(testSRT))
+	result = file.Retime(SubtitleRetime{Source: target, Target: target, OffsetFrames: -24 * 10})
+	if result.Cues != 1 || result.Dropped != 1 || file.Cues[0].Start != 50040 {
+		t.Errorf("result = %+v, first start %d, expected 1 cue at 50040 ms", result, file.Cues[0].Start)
+	}
+}
+
+// TestSubtitleRetimeKeepsNotes moves the notes of a dropped cue to the next one
+func TestSubtitleRetimeKeepsNotes(t *testing.T) {
+	input := "WEBVTT\n\nNOTE first\n\n00:00:01.000 --> 00:00:02.000\nOne\n\nNOTE second\n\n00:00:10.000 --> 00:00:11.000\nTwo\n"
+	file, err := ParseSubtitles(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseSubtitles failed: %v", err)
+	}
+	format := GetFPSFormat("25 fps")
+	result := file.Retime(SubtitleRetime{Source: format, Target: format, OffsetFrames: -5 * 25})
+	if result.Dropped != 1 {
+		t.Fatalf("dropped %d cues, expected 1", result.Dropped)
+	}
+
+	var out bytes.Buffer
+	if err := WriteSubtitles(&out, file, SubtitleWebVTT); err != nil {
+		t.Fatalf("WriteSubtitles failed: %v", err)
+	}
+	expected := "WEBVTT\n\nNOTE first\n\nNOTE second\n\n00:00:05.000 --> 00:00:06.000\nTwo\n"
+	if out.String() != expected {
+		t.Errorf("retimed =\n%s\nexpected\n%s", out.String(), expected)
+	}
+}
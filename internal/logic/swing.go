@@ -0,0 +1,65 @@
+package logic
+
+import "fmt"
+
+// Swing amounts in MPC style: the share of a pair of notes taken by the first one
+const (
+	SwingStraight = 50.0
+	SwingTriplet  = 200.0 / 3 // The first note is two triplets long
+	SwingMax      = 75.0      // The first note is a dotted note
+)
+
+// SwingResult holds the timing of a swung pair of notes
+type SwingResult struct {
+	Percent     float64 // MPC swing, 50 straight to 75
+	TripletFeel float64 // 0 straight, 100 a triplet shuffle, 150 at 75% swing
+	PairMS      float64 // An on-beat and an off-beat note together
+
+	OnBeatMS       float64 // From the on-beat note to the off-beat note
+	OffBeatMS      float64 // From the off-beat note to the next on-beat note
+	OffsetMS       float64 // How late the off-beat note is against straight timing
+	OnBeatSamples  float64
+	OffBeatSamples float64
+	OffsetSamples  float64
+}
+
+// CalculateSwing times a swung pair of notes at a tempo. The note value is a multiplier of
+// a quarter note as in GetTempoData, e.g. 0.5 to swing eighths, and percent is MPC swing.
+func CalculateSwing(bpm, noteMult, percent float64, sampleRate int) (SwingResult, error) {
+	if bpm <= 0 || noteMult <= 0 {
+		return SwingResult{}, fmt.Errorf("tempo and note value must be positive")
+	}
+	if percent < SwingStraight || percent > SwingMax {
+		return SwingResult{}, fmt.Errorf("swing must be %g to %g%%", SwingStraight, SwingMax)
+	}
+
+	pair := GetTempoData(bpm, 2*noteMult).DelayMS
+	r := SwingResult{
+		Percent:     percent,
+		TripletFeel: TripletFeelFromSwing(percent),
+		PairMS:      pair,
+		OnBeatMS:    pair * percent / 100,
+		OffBeatMS:   pair * (100 - percent) / 100,
+		OffsetMS:    pair * (percent - SwingStraight) / 100,
+	}
+	r.OnBeatSamples = DelaySamples(r.OnBeatMS, sampleRate)
+	r.OffBeatSamples = DelaySamples(r.OffBeatMS, sampleRate)
+	r.OffsetSamples = DelaySamples(r.OffsetMS, sampleRate)
+	return r, nil
+}
+
+// TripletFeelFromSwing converts MPC swing to the share of the way to a triplet shuffle, in percent
+func TripletFeelFromSwing(percent float64) float64 {
+	return (percent - SwingStraight) / (SwingTriplet - SwingStraight) * 100
+}
+
+// SwingFromTripletFeel converts a share of the way to a triplet shuffle to MPC swing
+func SwingFromTripletFeel(feel float64) float64 {
+	return SwingStraight + feel/100*(SwingTriplet-SwingStraight)
+}
+
+// SwingFromOffset converts how late the off-beat note is, in ms, to MPC swing
+func SwingFromOffset(bpm, noteMult, offsetMS float64) float64 {
+	pair := GetTempoData(bpm, 2*noteMult).DelayMS
+	return SwingStraight + offsetMS/pair*100
+}
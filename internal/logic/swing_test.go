@@ -0,0 +1,57 @@
+package logic
+
+import (
+	"math"
+	"testing"
+)
+
+// TestCalculateSwing times swung eighths at 120 BPM
+func TestCalculateSwing(t *testing.T) {
+	tests := []struct {
+		percent                       float64
+		onBeat, offBeat, offset, feel float64
+	}{
+		{50, 250, 250, 0, 0},
+		{58, 290, 210, 40, 48},
+		{SwingTriplet, 1000.0 / 3, 500.0 / 3, 250.0 / 3, 100},
+		{75, 375, 125, 125, 150},
+	}
+	for _, tc := range tests {
+		r, err := CalculateSwing(120, 0.5, tc.percent, 48000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []float64{r.OnBeatMS, r.OffBeatMS, r.OffsetMS, r.TripletFeel}
+		for i, expected := range []float64{tc.onBeat, tc.offBeat, tc.offset, tc.feel} {
+			if math.Abs(got[i]-expected) > 1e-9 {
+				t.Errorf("%.2f%%: got on %.3f, off %.3f, offset %.3f ms, feel %.2f%%, expected %v",
+					tc.percent, r.OnBeatMS, r.OffBeatMS, r.OffsetMS, r.TripletFeel, []float64{tc.onBeat, tc.offBeat, tc.offset, tc.feel})
+				break
+			}
+		}
+		if math.Abs(r.OnBeatSamples-tc.onBeat*48) > 1e-6 || math.Abs(r.OffsetSamples-tc.offset*48) > 1e-6 {
+			t.Errorf("%.2f%%: on %.3f, offset %.3f samples at 48 kHz", tc.percent, r.OnBeatSamples, r.OffsetSamples)
+		}
+	}
+
+	if _, err := CalculateSwing(120, 0.5, 80, 48000); err == nil {
+		t.Error("80% swing: expected an error")
+	}
+	if _, err := CalculateSwing(120, 0.5, 49, 48000); err == nil {
+		t.Error("49% swing: expected an error")
+	}
+}
+
+// TestSwingConversions round-trips between MPC swing, triplet feel and offset
+func TestSwingConversions(t *testing.T) {
+	if got := SwingFromTripletFeel(100); math.Abs(got-SwingTriplet) > 1e-9 {
+		t.Errorf("100%% triplet feel = %.3f%% swing, expected 66.667%%", got)
+	}
+	if got := SwingFromTripletFeel(TripletFeelFromSwing(62)); math.Abs(got-62) > 1e-9 {
+		t.Errorf("62%% round trip = %.6f%%", got)
+	}
+	// Sixteenths at 100 BPM: a pair is 300 ms, 60% swing delays the off-beat by 30 ms
+	if got := SwingFromOffset(100, 0.25, 30); math.Abs(got-60) > 1e-9 {
+		t.Errorf("30 ms on 1/16 at 100 BPM = %.3f%% swing, expected 60%%", got)
+	}
+}
@@ -0,0 +1,112 @@
+package logic
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// TapTempo estimates a tempo from taps, averaging the latest intervals and
+// dropping those that stray too far from the median (missed or doubled taps)
+type TapTempo struct {
+	Window    int           // Intervals averaged, older taps are forgotten
+	Timeout   time.Duration // A longer pause starts a new series
+	Tolerance float64       // Largest deviation from the median interval kept, e.g. 0.25 for 25%
+	taps      []time.Time
+}
+
+// TapTempoResult is the estimate after a tap
+type TapTempoResult struct {
+	BPM        float64 // 0 until there are two taps
+	Taps       int     // Taps in the current series, within the window
+	Intervals  int     // Intervals averaged
+	Rejected   int     // Intervals dropped as outliers
+	SpreadBPM  float64 // Standard deviation of the averaged intervals, as a tempo
+	Confidence float64 // 0..1, grows with the number of intervals and their steadiness
+	Restarted  bool    // The tap started a new series after a pause
+}
+
+// tapConfidenceIntervals is how many steady intervals give full confidence
+const tapConfidenceIntervals = 4
+
+// NewTapTempo returns a tapper averaging 8 intervals that resets after 2 seconds
+func NewTapTempo() *TapTempo {
+	return &TapTempo{Window: 8, Timeout: 2 * time.Second, Tolerance: 0.25}
+}
+
+// Reset forgets all taps
+func (t *TapTempo) Reset() {
+	t.taps = nil
+}
+
+// Tap adds a tap and returns the new estimate. A pause longer than the timeout,
+// or than twice the current beat at slow tempos, starts a new series.
+func (t *TapTempo) Tap(at time.Time) TapTempoResult {
+	restarted := false
+	if len(t.taps) > 0 {
+		pause := at.Sub(t.taps[len(t.taps)-1])
+		timeout := t.Timeout
+		if current := t.estimate(); current.BPM > 0 {
+			timeout = max(timeout, time.Duration(2*60/current.BPM*float64(time.Second)))
+		}
+		if pause < 0 || pause > timeout {
+			t.taps = nil
+			restarted = true
+		}
+	}
+
+	t.taps = append(t.taps, at)
+	if window := max(t.Window, 1); len(t.taps) > window+1 {
+		t.taps = t.taps[len(t.taps)-window-1:]
+	}
+
+	result := t.estimate()
+	result.Restarted = restarted
+	return result
+}
+
+// estimate averages the intervals between the stored taps
+func (t *TapTempo) estimate() TapTempoResult {
+	result := TapTempoResult{Taps: len(t.taps)}
+	if len(t.taps) < 2 {
+		return result
+	}
+
+	intervals := make([]float64, len(t.taps)-1)
+	for i := range intervals {
+		intervals[i] = t.taps[i+1].Sub(t.taps[i]).Seconds()
+	}
+	sorted := append([]float64(nil), intervals...)
+	sort.Float64s(sorted)
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + median) / 2
+	}
+
+	sum, sumSquares := 0.0, 0.0
+	for _, interval := range intervals {
+		if math.Abs(interval-median) > t.Tolerance*median {
+			result.Rejected++
+			continue
+		}
+		result.Intervals++
+		sum += interval
+		sumSquares += interval * interval
+	}
+	if result.Intervals == 0 || sum <= 0 {
+		return result
+	}
+
+	mean := sum / float64(result.Intervals)
+	deviation := math.Sqrt(math.Max(0, sumSquares/float64(result.Intervals)-mean*mean))
+	variation := deviation / mean // Relative spread of the intervals
+	result.BPM = 60 / mean
+	result.SpreadBPM = result.BPM * variation
+
+	// Steadiness falls from 1 for identical intervals to 0 at a 10% spread,
+	// and counts fully once there are enough intervals
+	steadiness := math.Max(0, 1-variation/0.1)
+	count := math.Min(1, float64(result.Intervals)/tapConfidenceIntervals)
+	result.Confidence = steadiness * count
+	return result
+}
@@ -0,0 +1,96 @@
+package logic
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+// tapAt returns taps at the given offsets in ms from a fixed start
+func tapAt(tapper *TapTempo, offsets ...int) TapTempoResult {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var result TapTempoResult
+	for _, offset := range offsets {
+		result = tapper.Tap(start.Add(time.Duration(offset) * time.Millisecond))
+	}
+	return result
+}
+
+// TestTapTempoSteady averages evenly spaced taps
+func TestTapTempoSteady(t *testing.T) {
+	tapper := NewTapTempo()
+	if result := tapAt(tapper, 0); result.BPM != 0 || result.Taps != 1 {
+		t.Errorf("one tap = %+v, expected no tempo yet", result)
+	}
+
+	tapper.Reset()
+	result := tapAt(tapper, 0, 500, 1000, 1500, 2000, 2500)
+	if math.Abs(result.BPM-120) > 1e-9 || result.Intervals != 5 || result.Rejected != 0 {
+		t.Errorf("steady taps = %+v, expected 120 BPM from 5 intervals", result)
+	}
+	if result.Confidence != 1 || result.SpreadBPM > 1e-9 {
+		t.Errorf("steady taps confidence %.2f spread %.3f, expected 1 and 0", result.Confidence, result.SpreadBPM)
+	}
+}
+
+// TestTapTempoWindow only averages the latest intervals
+func TestTapTempoWindow(t *testing.T) {
+	tapper := &TapTempo{Window: 3, Timeout: 2 * time.Second, Tolerance: 0.25}
+
+	// Slowing from 500 ms to 600 ms intervals; the window ends up holding only the 600s
+	result := tapAt(tapper, 0, 500, 1000, 1500, 2100, 2700, 3300)
+	if math.Abs(result.BPM-100) > 1e-9 || result.Taps != 4 || result.Intervals != 3 {
+		t.Errorf("window = %+v, expected 100 BPM from the last 3 intervals", result)
+	}
+}
+
+// TestTapTempoOutliers drops a missed tap and lowers the confidence of uneven tapping
+func TestTapTempoOutliers(t *testing.T) {
+	tapper := NewTapTempo()
+
+	// The tap at 1500 was missed, leaving a 1000 ms interval
+	result := tapAt(tapper, 0, 500, 1000, 2000, 2500, 3000)
+	if math.Abs(result.BPM-120) > 1e-9 || result.Rejected != 1 || result.Intervals != 4 {
+		t.Errorf("missed tap = %+v, expected 120 BPM with 1 interval dropped", result)
+	}
+
+	tapper.Reset()
+	uneven := tapAt(tapper, 0, 480, 1010, 1490, 2030, 2500)
+	if uneven.Confidence >= 1 || uneven.Confidence <= 0 || uneven.SpreadBPM <= 0 {
+		t.Errorf("uneven taps = %+v, expected partial confidence", uneven)
+	}
+	if math.Abs(uneven.BPM-120) > 0.5 {
+		t.Errorf("uneven taps = %.2f BPM, expected about 120", uneven.BPM)
+	}
+}
+
+// TestTapTempoPause starts a new series after a pause
+func TestTapTempoPause(t *testing.T) {
+	tapper := NewTapTempo()
+	result := tapAt(tapper, 0, 500, 1000, 1500, 4000)
+	if !result.Restarted || result.Taps != 1 || result.BPM != 0 {
+		t.Errorf("after a pause = %+v, expected a new series", result)
+	}
+	result = tapAt(tapper, 4600)
+	if result.Restarted || math.Abs(result.BPM-100) > 1e-9 {
+		t.Errorf("new series = %+v, expected 100 BPM", result)
+	}
+
+	// Once a slow tempo is known the timeout grows to twice the beat, so 2.4 s beats
+	// (25 BPM) after a first 1.9 s one go past the 2 s timeout without a restart
+	tapper.Reset()
+	result = tapAt(tapper, 0, 1900, 4300, 6700, 9100)
+	if result.Restarted || result.Taps != 5 {
+		t.Errorf("slow taps = %+v, expected one series of 5 taps", result)
+	}
+	if math.Abs(result.BPM-60/2.275) > 1e-9 {
+		t.Errorf("slow taps = %.3f BPM, expected %.3f", result.BPM, 60/2.275)
+	}
+
+	// Without a tempo yet, a first 2.4 s interval is a pause
+	tapper.Reset()
+	result = tapAt(tapper, 0, 2400)
+	if !result.Restarted || result.Taps != 1 {
+		t.Errorf("first slow tap = %+v, expected a new series", result)
+	}
+}
@@ -0,0 +1,332 @@
+package logic
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// TempoCurve is the shape of a tempo ramp between two segments
+type TempoCurve int
+
+const (
+	TempoConstant    TempoCurve = iota
+	TempoLinear                 // Tempo changes by the same amount every beat
+	TempoExponential            // Tempo changes by the same ratio every beat
+)
+
+// TempoCurveNames names the curves in the tempo map text format
+var TempoCurveNames = []string{"constant", "linear", "exp"}
+
+// TempoSegment is a stretch of a tempo map from a bar line up to the next segment.
+// Tempos are quarter notes per minute; ramps reach EndBPM where the next segment starts.
+type TempoSegment struct {
+	Bar       int
+	Signature TimeSignature
+	StartBPM  float64
+	EndBPM    float64 // Same as StartBPM for constant tempo
+	Curve     TempoCurve
+}
+
+// TempoMap is a list of tempo and meter changes, each starting on a bar line.
+// Positions are ticks from bar 1; before bar 1 the first segment's tempo and meter continue.
+type TempoMap struct {
+	PPQ      int
+	Segments []TempoSegment
+}
+
+// TempoMap returns a single-segment map for a constant tempo grid
+func (g MusicalGrid) TempoMap() *TempoMap {
+	return &TempoMap{
+		PPQ: g.PPQ,
+		Segments: []TempoSegment{
+			{Bar: 1, Signature: g.Signature, StartBPM: g.QuarterBPM(), EndBPM: g.QuarterBPM()},
+		},
+	}
+}
+
+// Validate checks that segments start at bar 1 in order, that every beat is a
+// whole number of ticks and that only segments followed by another one ramp
+func (m *TempoMap) Validate() error {
+	if m.PPQ <= 0 {
+		return errors.New("PPQ must be positive")
+	}
+	if len(m.Segments) == 0 || m.Segments[0].Bar != 1 {
+		return errors.New("the tempo map must start at bar 1")
+	}
+	for i, segment := range m.Segments {
+		if i > 0 && segment.Bar <= m.Segments[i-1].Bar {
+			return fmt.Errorf("bar %d: segments must be in bar order", segment.Bar)
+		}
+		grid := MusicalGrid{BPM: segment.StartBPM, Signature: segment.Signature, PPQ: m.PPQ}
+		if err := grid.Validate(); err != nil {
+			return fmt.Errorf("bar %d: %v", segment.Bar, err)
+		}
+		if segment.EndBPM <= 0 {
+			return fmt.Errorf("bar %d: tempo must be positive", segment.Bar)
+		}
+		if segment.Curve != TempoConstant && i == len(m.Segments)-1 {
+			return fmt.Errorf("bar %d: the last segment can't ramp, add a segment where the ramp ends", segment.Bar)
+		}
+	}
+	return nil
+}
+
+// tempoSpan is a segment placed on the timeline
+type tempoSpan struct {
+	TempoSegment
+	startTicks   int
+	startSeconds float64
+	ticks        int // Length in ticks, 0 for the open-ended last segment
+}
+
+// spans places the segments one after another
+func (m *TempoMap) spans() []tempoSpan {
+	spans := make([]tempoSpan, len(m.Segments))
+	ticks, seconds := 0, 0.0
+	for i, segment := range m.Segments {
+		spans[i] = tempoSpan{TempoSegment: segment, startTicks: ticks, startSeconds: seconds}
+		if i+1 < len(m.Segments) {
+			bars := m.Segments[i+1].Bar - segment.Bar
+			spans[i].ticks = bars * segment.ticksPerBar(m.PPQ)
+			ticks += spans[i].ticks
+			seconds += spans[i].seconds(float64(spans[i].ticks), m.PPQ)
+		}
+	}
+	return spans
+}
+
+func (s TempoSegment) ticksPerBar(ppq int) int {
+	return ppq * 4 / s.Signature.Denominator * s.Signature.Numerator
+}
+
+// seconds integrates the beat length over the first ticks of a segment
+func (s tempoSpan) seconds(ticks float64, ppq int) float64 {
+	a, b := s.StartBPM, s.EndBPM
+	q := ticks / float64(ppq)
+	length := float64(s.ticks) / float64(ppq) // Ramp length in quarter notes
+	switch {
+	case s.Curve == TempoConstant || a == b || s.ticks == 0:
+		return 60 * q / a
+	case s.Curve == TempoLinear:
+		return 60 * length / (b - a) * math.Log(1+(b-a)*q/(length*a))
+	default:
+		r := math.Log(b / a)
+		return 60 * length / (a * r) * (1 - math.Exp(-r*q/length))
+	}
+}
+
+// ticksAt inverts seconds: the ticks into a segment after a time
+func (s tempoSpan) ticksAt(seconds float64, ppq int) float64 {
+	a, b := s.StartBPM, s.EndBPM
+	length := float64(s.ticks) / float64(ppq)
+	var q float64
+	switch {
+	case s.Curve == TempoConstant || a == b || s.ticks == 0:
+		q = seconds * a / 60
+	case s.Curve == TempoLinear:
+		q = length * a / (b - a) * (math.Exp(seconds*(b-a)/(60*length)) - 1)
+	default:
+		r := math.Log(b / a)
+		q = -length / r * math.Log(1-seconds*a*r/(60*length))
+	}
+	return q * float64(ppq)
+}
+
+// tempoAt returns the tempo after the first ticks of a segment
+func (s tempoSpan) tempoAt(ticks float64) float64 {
+	if s.Curve == TempoConstant || s.ticks == 0 {
+		return s.StartBPM
+	}
+	x := ticks / float64(s.ticks)
+	if s.Curve == TempoLinear {
+		return s.StartBPM + (s.EndBPM-s.StartBPM)*x
+	}
+	return s.StartBPM * math.Pow(s.EndBPM/s.StartBPM, x)
+}
+
+// spanAtTicks returns the segment a tick position falls in; positions before bar 1 use the first
+func spanAtTicks(spans []tempoSpan, ticks float64) tempoSpan {
+	i := sort.Search(len(spans), func(i int) bool { return float64(spans[i].startTicks) > ticks })
+	return spans[max(i-1, 0)]
+}
+
+// TicksToSeconds returns the time of a tick position counted from bar 1
+func (m *TempoMap) TicksToSeconds(ticks float64) float64 {
+	if ticks < 0 {
+		return ticks / float64(m.PPQ) * 60 / m.Segments[0].StartBPM
+	}
+	span := spanAtTicks(m.spans(), ticks)
+	return span.startSeconds + span.seconds(ticks-float64(span.startTicks), m.PPQ)
+}
+
+// SecondsToTicks returns the tick position of a time counted from bar 1
+func (m *TempoMap) SecondsToTicks(seconds float64) float64 {
+	if seconds < 0 {
+		return seconds * m.Segments[0].StartBPM / 60 * float64(m.PPQ)
+	}
+	spans := m.spans()
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].startSeconds > seconds })
+	span := spans[max(i-1, 0)]
+	return float64(span.startTicks) + span.ticksAt(seconds-span.startSeconds, m.PPQ)
+}
+
+// TempoAt returns the quarter-note tempo at a tick position
+func (m *TempoMap) TempoAt(ticks float64) float64 {
+	if ticks < 0 {
+		return m.Segments[0].StartBPM
+	}
+	span := spanAtTicks(m.spans(), ticks)
+	return span.tempoAt(ticks - float64(span.startTicks))
+}
+
+// SignatureAt returns the time signature of a bar
+func (m *TempoMap) SignatureAt(bar int) TimeSignature {
+	signature := m.Segments[0].Signature
+	for _, segment := range m.Segments {
+		if segment.Bar <= bar {
+			signature = segment.Signature
+		}
+	}
+	return signature
+}
+
+// BBTToTicks returns the ticks from bar 1 to a position, checking the beat and tick ranges for its bar's meter
+func (m *TempoMap) BBTToTicks(b BBT) (int, error) {
+	spans := m.spans()
+	span := spans[0]
+	for _, s := range spans {
+		if s.Bar <= b.Bar {
+			span = s
+		}
+	}
+	grid := MusicalGrid{Signature: span.Signature, PPQ: m.PPQ}
+	inBar, err := grid.BBTToTicks(BBT{Bar: 1, Beat: b.Beat, Tick: b.Tick})
+	if err != nil {
+		return 0, err
+	}
+	return span.startTicks + (b.Bar-span.Bar)*span.ticksPerBar(m.PPQ) + inBar, nil
+}
+
+// TicksToBBT returns the position of a whole tick count from bar 1
+func (m *TempoMap) TicksToBBT(ticks int) BBT {
+	span := spanAtTicks(m.spans(), float64(ticks))
+	grid := MusicalGrid{Signature: span.Signature, PPQ: m.PPQ}
+	b := grid.TicksToBBT(ticks - span.startTicks)
+	b.Bar += span.Bar - 1
+	return b
+}
+
+// ParseTempoMap reads the tempo map text format, one segment per line:
+//
+//	ppq 960
+//	# bar  tempo    meter  curve
+//	1      120      4/4
+//	9      90>120   4/4    linear
+//	17     120      7/8
+//
+// A ramp "90>120" reaches its end tempo at the next segment, linear unless "exp" is given.
+// The meter carries over from the previous segment when left out; the first defaults to 4/4.
+func ParseTempoMap(r io.Reader) (*TempoMap, error) {
+	m := &TempoMap{PPQ: 960}
+	signature := TimeSignature{4, 4}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		lineError := func(format string, args ...interface{}) error {
+			return fmt.Errorf("line %d: %s", lineNumber, fmt.Sprintf(format, args...))
+		}
+
+		if strings.EqualFold(fields[0], "ppq") {
+			if len(fields) != 2 {
+				return nil, lineError("use ppq <ticks per quarter note>")
+			}
+			ppq, err := strconv.Atoi(fields[1])
+			if err != nil || ppq <= 0 {
+				return nil, lineError("invalid PPQ %q", fields[1])
+			}
+			m.PPQ = ppq
+			continue
+		}
+
+		if len(fields) < 2 {
+			return nil, lineError("use <bar> <tempo> [meter] [curve]")
+		}
+		bar, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return nil, lineError("invalid bar %q", fields[0])
+		}
+		segment := TempoSegment{Bar: bar}
+
+		startText, endText, ramp := strings.Cut(fields[1], ">")
+		segment.StartBPM, err = strconv.ParseFloat(startText, 64)
+		if err != nil || segment.StartBPM <= 0 {
+			return nil, lineError("invalid tempo %q", fields[1])
+		}
+		segment.EndBPM = segment.StartBPM
+		if ramp {
+			segment.EndBPM, err = strconv.ParseFloat(endText, 64)
+			if err != nil || segment.EndBPM <= 0 {
+				return nil, lineError("invalid tempo %q", fields[1])
+			}
+			segment.Curve = TempoLinear
+		}
+
+		for _, field := range fields[2:] {
+			switch strings.ToLower(field) {
+			case "linear", "lin":
+				segment.Curve = TempoLinear
+			case "exp", "exponential":
+				segment.Curve = TempoExponential
+			case "constant":
+				segment.Curve = TempoConstant
+			default:
+				signature, err = ParseTimeSignature(field)
+				if err != nil {
+					return nil, lineError("%v", err)
+				}
+			}
+		}
+		if !ramp || segment.Curve == TempoConstant {
+			segment.Curve, segment.EndBPM = TempoConstant, segment.StartBPM
+		}
+		segment.Signature = signature
+		m.Segments = append(m.Segments, segment)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// String writes the map in the format read by ParseTempoMap
+func (m *TempoMap) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "ppq %d\n# bar\ttempo\tmeter\tcurve\n", m.PPQ)
+	for _, segment := range m.Segments {
+		tempo := strconv.FormatFloat(segment.StartBPM, 'f', -1, 64)
+		if segment.Curve != TempoConstant {
+			tempo += ">" + strconv.FormatFloat(segment.EndBPM, 'f', -1, 64)
+		}
+		fmt.Fprintf(&b, "%d\t%s\t%s", segment.Bar, tempo, segment.Signature)
+		if segment.Curve != TempoConstant {
+			b.WriteString("\t" + TempoCurveNames[segment.Curve])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,118 @@
+package logic
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+const accelerandoMap = `ppq 960
+# 8 bars from 90 to 120, then 7/8
+1	90>120	4/4	linear
+9	120	7/8
+`
+
+// TestTempoMapAccelerando integrates linear and exponential ramps
+func TestTempoMapAccelerando(t *testing.T) {
+	m, err := ParseTempoMap(strings.NewReader(accelerandoMap))
+	if err != nil {
+		t.Fatalf("ParseTempoMap failed: %v", err)
+	}
+
+	// 32 quarter notes ramping linearly from 90 to 120: 60 * 32 / 30 * ln(120/90)
+	end, _ := m.BBTToTicks(BBT{9, 1, 0})
+	expected := 64 * math.Log(4.0/3)
+	if got := m.TicksToSeconds(float64(end)); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("linear accelerando = %.6f s, expected %.6f", got, expected)
+	}
+	if got := m.TempoAt(float64(end) / 2); math.Abs(got-105) > 1e-9 {
+		t.Errorf("tempo half way = %.3f", got)
+	}
+
+	// One 7/8 bar at 120 after the ramp
+	next, _ := m.BBTToTicks(BBT{10, 1, 0})
+	if got := m.TicksToSeconds(float64(next)) - m.TicksToSeconds(float64(end)); math.Abs(got-1.75) > 1e-9 {
+		t.Errorf("7/8 bar = %.6f s", got)
+	}
+
+	// Same tempos, constant ratio per beat: 60 * 32 / (90 * ln(4/3)) * (1 - 90/120)
+	m.Segments[0].Curve = TempoExponential
+	expected = 480 / (90 * math.Log(4.0/3))
+	if got := m.TicksToSeconds(float64(end)); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("exponential accelerando = %.6f s, expected %.6f", got, expected)
+	}
+	if got := m.TempoAt(float64(end) / 2); math.Abs(got-math.Sqrt(90*120)) > 1e-9 {
+		t.Errorf("exponential tempo half way = %.3f", got)
+	}
+}
+
+// TestTempoMapRoundTrip converts positions to time and back across ramps, meter changes and pre-roll
+func TestTempoMapRoundTrip(t *testing.T) {
+	for _, curve := range []TempoCurve{TempoLinear, TempoExponential} {
+		m, _ := ParseTempoMap(strings.NewReader(accelerandoMap))
+		m.Segments[0].Curve = curve
+		for _, ticks := range []float64{-1000, 0, 1234.5, 15360, 30720, 31000, 100000} {
+			seconds := m.TicksToSeconds(ticks)
+			if got := m.SecondsToTicks(seconds); math.Abs(got-ticks) > 1e-6 {
+				t.Errorf("%s: %.1f ticks → %.6f s → %.6f ticks", TempoCurveNames[curve], ticks, seconds, got)
+			}
+		}
+	}
+
+	m, _ := ParseTempoMap(strings.NewReader(accelerandoMap))
+	testCases := []struct {
+		position BBT
+		ticks    int
+	}{
+		{BBT{9, 2, 0}, 31200}, // 7/8 beats are 480 ticks
+		{BBT{12, 7, 479}, 30720 + 3*3360 + 6*480 + 479},
+		{BBT{0, 4, 0}, -960}, // Pre-roll keeps the first meter
+	}
+	for _, tc := range testCases {
+		ticks, err := m.BBTToTicks(tc.position)
+		if err != nil || ticks != tc.ticks {
+			t.Errorf("BBTToTicks(%s) = %d, %v, expected %d", tc.position, ticks, err, tc.ticks)
+		}
+		if got := m.TicksToBBT(tc.ticks); got != tc.position {
+			t.Errorf("TicksToBBT(%d) = %s, expected %s", tc.ticks, got, tc.position)
+		}
+	}
+	if _, err := m.BBTToTicks(BBT{9, 8, 0}); err == nil {
+		t.Error("expected an error for beat 8 in 7/8")
+	}
+}
+
+// TestParseTempoMap checks the text format in both directions and its errors
+func TestParseTempoMap(t *testing.T) {
+	m, err := ParseTempoMap(strings.NewReader("1 120 3/4\n5 60>80 exp\n9 100 5/8\n13 100 constant"))
+	if err != nil {
+		t.Fatalf("ParseTempoMap failed: %v", err)
+	}
+	if m.PPQ != 960 || m.Segments[1].Signature != (TimeSignature{3, 4}) || m.Segments[1].Curve != TempoExponential {
+		t.Errorf("parsed %+v", m)
+	}
+
+	expected := "ppq 960\n# bar\ttempo\tmeter\tcurve\n1\t120\t3/4\n5\t60>80\t3/4\texp\n9\t100\t5/8\n13\t100\t5/8\n"
+	if got := m.String(); got != expected {
+		t.Errorf("String() =\n%s", got)
+	}
+	again, err := ParseTempoMap(strings.NewReader(m.String()))
+	if err != nil || again.String() != expected {
+		t.Errorf("round trip = %v, %v", again, err)
+	}
+
+	errorCases := []struct {
+		input, message string
+	}{
+		{"1 120\n5 90>120", "the last segment can't ramp"},
+		{"2 120", "must start at bar 1"},
+		{"1 120\n1 90", "in bar order"},
+		{"1 120\n5 fast", "line 2: invalid tempo"},
+		{"ppq 24\n1 120 7/64", "whole number of ticks"},
+	}
+	for _, tc := range errorCases {
+		if _, err := ParseTempoMap(strings.NewReader(tc.input)); err == nil || !strings.Contains(err.Error(), tc.message) {
+			t.Errorf("ParseTempoMap(%q) error = %v, expected %q", tc.input, err, tc.message)
+		}
+	}
+}
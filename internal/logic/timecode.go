@@ -3,6 +3,8 @@ package logic
 import (
 	"fmt"
 	"math"
+	"strconv"
+	"strings"
 )
 
 type TimecodeResult struct {
@@ -11,6 +13,7 @@ type TimecodeResult struct {
 	Seconds     int
 	Frames      int
 	TotalFrames int
+	Negative    bool // Components hold the magnitude of a negative result
 	Timecode    string
 }
 
@@ -28,9 +31,16 @@ var FPSFormats = []FPSFormat{
 	{"29.97 fps", 30000.0 / 1001.0, false}, // Exact NTSC video rate
 	{"29.97 fps (df)", 30000.0 / 1001.0, true},
 	{"30 fps", 30.0, false},
+	{"47.952 fps", 48000.0 / 1001.0, false}, // HFR film at NTSC pull-down
+	{"48 fps", 48.0, false},
 	{"50 fps", 50.0, false},
 	{"59.94 fps", 60000.0 / 1001.0, false}, // Exact NTSC 60i rate
+	{"59.94 fps (df)", 60000.0 / 1001.0, true},
 	{"60 fps", 60.0, false},
+	{"96 fps", 96.0, false},
+	{"100 fps", 100.0, false},
+	{"119.88 fps", 120000.0 / 1001.0, false}, // HFR at NTSC pull-down
+	{"120 fps", 120.0, false},
 }
 
 func GetFPSFormat(name string) FPSFormat {
@@ -42,23 +52,98 @@ func GetFPSFormat(name string) FPSFormat {
 	return FPSFormats[6] // Default to 30 fps
 }
 
+// NominalRate returns the integer frame count used for timecode labels
+// (e.g. 30 for 29.97 fps, 60 for 59.94 fps)
+func (f FPSFormat) NominalRate() int {
+	return int(math.Round(f.FPS))
+}
+
+// ThreeDigitFrames reports whether frame labels need three digits (HH:MM:SS:FFF)
+func (f FPSFormat) ThreeDigitFrames() bool {
+	return f.NominalRate() > 99
+}
+
+// DroppedFramesPerMinute returns how many frame labels are skipped at the start
+// of each minute (except every 10th minute) in drop-frame mode.
+// Drop-frame counts skip 2 labels per minute for every 30 frames of nominal rate,
+// so 29.97 fps drops 2 frames and 59.94 fps drops 4 frames.
+func (f FPSFormat) DroppedFramesPerMinute() int {
+	if !f.DropFrame {
+		return 0
+	}
+	return f.NominalRate() / 15
+}
+
+// DropFrameVariant returns the drop-frame or non-drop-frame counterpart of a format
+// (e.g. "29.97 fps" <-> "29.97 fps (df)"), or the format itself if there is none
+func DropFrameVariant(format FPSFormat, dropFrame bool) FPSFormat {
+	if format.DropFrame == dropFrame {
+		return format
+	}
+
+	name := strings.TrimSuffix(format.Name, " (df)")
+	if dropFrame {
+		name += " (df)"
+	}
+	for _, candidate := range FPSFormats {
+		if candidate.Name == name {
+			return candidate
+		}
+	}
+	for _, candidate := range FPSFormats {
+		if candidate.FPS == format.FPS && candidate.DropFrame == dropFrame {
+			return candidate
+		}
+	}
+	return format
+}
+
+// ResultMode selects how results outside 00:00:00:00-23:59:59:FF are shown
+type ResultMode int
+
+const (
+	ResultSigned  ResultMode = iota // Negative results keep their sign, e.g. -00:00:03:12
+	ResultWrap24h                   // Results wrap around midnight like a timecode reader
+)
+
+var ResultModeNames = []string{"Signed", "24h Wrap"}
+
+func GetResultMode(name string) ResultMode {
+	for i, modeName := range ResultModeNames {
+		if modeName == name {
+			return ResultMode(i)
+		}
+	}
+	return ResultSigned
+}
+
+// FramesPer24Hours returns the number of frames in a full day of timecode
+func FramesPer24Hours(format FPSFormat) int {
+	return TimecodeToFrames(24, 0, 0, 0, format)
+}
+
+// ApplyResultMode wraps a frame count into a single day in 24h wrap mode
+// and leaves it unchanged in signed mode
+func ApplyResultMode(totalFrames int, format FPSFormat, mode ResultMode) int {
+	if mode != ResultWrap24h {
+		return totalFrames
+	}
+	day := FramesPer24Hours(format)
+	return ((totalFrames % day) + day) % day
+}
+
 // TimecodeToFrames converts timecode components to total frames
 func TimecodeToFrames(hours, minutes, seconds, frames int, format FPSFormat) int {
+	frameRate := format.NominalRate()
+	totalFrames := ((hours*3600 + minutes*60 + seconds) * frameRate) + frames
+
 	if format.DropFrame {
-		// Drop frame calculation for 29.97 fps
-		// Drop 2 frames every minute except every 10th minute
+		// Drop frame labels every minute except every 10th minute
 		totalMinutes := hours*60 + minutes
-		dropFrames := 2 * (totalMinutes - totalMinutes/10)
-
-		frameRate := int(math.Round(format.FPS))
-		totalFrames := ((hours*3600 + minutes*60 + seconds) * frameRate) + frames - dropFrames
-		return totalFrames
-	} else {
-		// Non-drop frame calculation
-		frameRate := int(math.Round(format.FPS))
-		totalFrames := ((hours*3600 + minutes*60 + seconds) * frameRate) + frames
-		return totalFrames
+		totalFrames -= format.DroppedFramesPerMinute() * (totalMinutes - totalMinutes/10)
 	}
+
+	return totalFrames
 }
 
 // FramesToTimecode converts total frames to timecode components
@@ -67,59 +152,153 @@ func FramesToTimecode(totalFrames int, format FPSFormat) TimecodeResult {
 		TotalFrames: totalFrames,
 	}
 
+	// Negative frame counts are split into components by magnitude
 	if totalFrames < 0 {
-		totalFrames = 0
+		result.Negative = true
+		totalFrames = -totalFrames
 	}
 
-	frameRate := int(math.Round(format.FPS))
+	frameRate := format.NominalRate()
 
 	if format.DropFrame {
-		// Drop frame calculation for 29.97 fps
-		// Add back dropped frames for calculation
-		d := totalFrames / 17982 // Number of 10-minute intervals
-		m := totalFrames % 17982
+		// Add back the dropped frame labels before splitting into components
+		dropFrames := format.DroppedFramesPerMinute()
+		framesPerMinute := frameRate*60 - dropFrames
+		framesPer10Minutes := frameRate*600 - 9*dropFrames
 
-		if m < 2 {
-			m = 2
-		}
+		d := totalFrames / framesPer10Minutes // Number of 10-minute intervals
+		m := totalFrames % framesPer10Minutes
 
-		adjustedFrames := totalFrames + 18*d + 2*((m-2)/1798)
+		if m < dropFrames {
+			m = dropFrames
+		}
 
-		result.Hours = adjustedFrames / (frameRate * 3600)
-		adjustedFrames %= (frameRate * 3600)
-		result.Minutes = adjustedFrames / (frameRate * 60)
-		adjustedFrames %= (frameRate * 60)
-		result.Seconds = adjustedFrames / frameRate
-		result.Frames = adjustedFrames % frameRate
-	} else {
-		// Non-drop frame calculation
-		result.Hours = totalFrames / (frameRate * 3600)
-		totalFrames %= (frameRate * 3600)
-		result.Minutes = totalFrames / (frameRate * 60)
-		totalFrames %= (frameRate * 60)
-		result.Seconds = totalFrames / frameRate
-		result.Frames = totalFrames % frameRate
+		totalFrames += 9*dropFrames*d + dropFrames*((m-dropFrames)/framesPerMinute)
 	}
 
-	result.Timecode = fmt.Sprintf("%02d:%02d:%02d:%02d", result.Hours, result.Minutes, result.Seconds, result.Frames)
+	result.Hours = totalFrames / (frameRate * 3600)
+	totalFrames %= (frameRate * 3600)
+	result.Minutes = totalFrames / (frameRate * 60)
+	totalFrames %= (frameRate * 60)
+	result.Seconds = totalFrames / frameRate
+	result.Frames = totalFrames % frameRate
+
+	result.Timecode = FormatTimecode(result.Hours, result.Minutes, result.Seconds, result.Frames, format)
+	if result.Negative {
+		result.Timecode = "-" + result.Timecode
+	}
 
 	return result
 }
 
+// FormatTimecode formats timecode components as HH:MM:SS:FF,
+// or HH:MM:SS:FFF for frame rates above 99 fps
+func FormatTimecode(hours, minutes, seconds, frames int, format FPSFormat) string {
+	if format.ThreeDigitFrames() {
+		return fmt.Sprintf("%02d:%02d:%02d:%03d", hours, minutes, seconds, frames)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d:%02d", hours, minutes, seconds, frames)
+}
+
+// ParseTimecode reads a timecode written as HH:MM:SS:FF with ':', ';' or '.'
+// separators and returns its signed frame count. Fields are right-aligned like
+// in the timecode entry, so "10:12" is 10 seconds and 12 frames.
+func ParseTimecode(text string, format FPSFormat) (int, error) {
+	text = strings.TrimSpace(text)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(text, "-")
+
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ':' || r == ';' || r == '.'
+	})
+	if len(parts) == 0 || len(parts) > 4 {
+		return 0, fmt.Errorf("invalid timecode %q", text)
+	}
+
+	// Right-align fields: [hours, minutes, seconds, frames]
+	fields := [4]int{}
+	for i, part := range parts {
+		value, err := strconv.Atoi(part)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid timecode %q", text)
+		}
+		fields[4-len(parts)+i] = value
+	}
+
+	validation := ValidateTimecode(fields[0], fields[1], fields[2], fields[3], format)
+	if !validation.Valid {
+		return 0, fmt.Errorf("invalid timecode %s: %s, use %s", text, validation.Message, validation.Suggestion.Timecode)
+	}
+
+	totalFrames := TimecodeToFrames(fields[0], fields[1], fields[2], fields[3], format)
+	if negative {
+		totalFrames = -totalFrames
+	}
+	return totalFrames, nil
+}
+
+// AddFrames adds two signed frame counts
+func AddFrames(frames1, frames2 int, format FPSFormat, mode ResultMode) TimecodeResult {
+	return FramesToTimecode(ApplyResultMode(frames1+frames2, format, mode), format)
+}
+
+// SubtractFrames subtracts two signed frame counts
+func SubtractFrames(frames1, frames2 int, format FPSFormat, mode ResultMode) TimecodeResult {
+	return FramesToTimecode(ApplyResultMode(frames1-frames2, format, mode), format)
+}
+
 // AddTimecodes adds two timecodes
-func AddTimecodes(h1, m1, s1, f1, h2, m2, s2, f2 int, format FPSFormat) TimecodeResult {
+func AddTimecodes(h1, m1, s1, f1, h2, m2, s2, f2 int, format FPSFormat, mode ResultMode) TimecodeResult {
 	frames1 := TimecodeToFrames(h1, m1, s1, f1, format)
 	frames2 := TimecodeToFrames(h2, m2, s2, f2, format)
-	return FramesToTimecode(frames1+frames2, format)
+	return AddFrames(frames1, frames2, format, mode)
 }
 
 // SubtractTimecodes subtracts two timecodes
-func SubtractTimecodes(h1, m1, s1, f1, h2, m2, s2, f2 int, format FPSFormat) TimecodeResult {
+func SubtractTimecodes(h1, m1, s1, f1, h2, m2, s2, f2 int, format FPSFormat, mode ResultMode) TimecodeResult {
 	frames1 := TimecodeToFrames(h1, m1, s1, f1, format)
 	frames2 := TimecodeToFrames(h2, m2, s2, f2, format)
-	totalFrames := frames1 - frames2
-	if totalFrames < 0 {
-		totalFrames = 0
+	return SubtractFrames(frames1, frames2, format, mode)
+}
+
+// TimecodeValidation reports whether a timecode label exists in an FPS format
+type TimecodeValidation struct {
+	Valid      bool
+	Message    string         // Reason the label is invalid (empty when valid)
+	Suggestion TimecodeResult // Nearest legal label
+}
+
+// ValidateTimecode checks that a timecode label exists in the given format.
+// Out-of-range fields are clamped to their maximum. Drop-frame labels that are
+// skipped at the start of a minute (e.g. 00:01:00;00 at 29.97 df) are moved
+// forward to the first existing frame of that minute, as editing systems do.
+func ValidateTimecode(hours, minutes, seconds, frames int, format FPSFormat) TimecodeValidation {
+	validation := TimecodeValidation{Valid: true}
+	frameRate := format.NominalRate()
+
+	if frames >= frameRate {
+		validation.Valid = false
+		validation.Message = fmt.Sprintf("frames must be below %d", frameRate)
+		frames = frameRate - 1
+	}
+	if seconds >= 60 {
+		validation.Valid = false
+		validation.Message = "seconds must be below 60"
+		seconds = 59
 	}
-	return FramesToTimecode(totalFrames, format)
+	if minutes >= 60 {
+		validation.Valid = false
+		validation.Message = "minutes must be below 60"
+		minutes = 59
+	}
+
+	dropFrames := format.DroppedFramesPerMinute()
+	if seconds == 0 && minutes%10 != 0 && frames < dropFrames {
+		validation.Valid = false
+		validation.Message = fmt.Sprintf("frames 00-%02d do not exist at minute %02d in drop-frame", dropFrames-1, minutes)
+		frames = dropFrames
+	}
+
+	validation.Suggestion = FramesToTimecode(TimecodeToFrames(hours, minutes, seconds, frames, format), format)
+	return validation
 }
@@ -0,0 +1,187 @@
+package logic
+
+import "testing"
+
+// TestDropFrameKnownValues checks drop-frame conversions against well-known reference points
+func TestDropFrameKnownValues(t *testing.T) {
+	testCases := []struct {
+		name        string
+		formatName  string
+		h, m, s, f  int
+		totalFrames int
+	}{
+		{"29.97 df first frame after 1 minute", "29.97 fps (df)", 0, 1, 0, 2, 1800},
+		{"29.97 df last frame before 1 minute", "29.97 fps (df)", 0, 0, 59, 29, 1799},
+		{"29.97 df 10 minutes", "29.97 fps (df)", 0, 10, 0, 0, 17982},
+		{"29.97 df 1 hour", "29.97 fps (df)", 1, 0, 0, 0, 107892},
+		{"59.94 df first frame after 1 minute", "59.94 fps (df)", 0, 1, 0, 4, 3600},
+		{"59.94 df last frame before 1 minute", "59.94 fps (df)", 0, 0, 59, 59, 3599},
+		{"59.94 df 10 minutes", "59.94 fps (df)", 0, 10, 0, 0, 35964},
+		{"59.94 df 1 hour", "59.94 fps (df)", 1, 0, 0, 0, 215784},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format := GetFPSFormat(tc.formatName)
+			if !format.DropFrame {
+				t.Fatalf("format %q is not drop-frame", tc.formatName)
+			}
+
+			frames := TimecodeToFrames(tc.h, tc.m, tc.s, tc.f, format)
+			if frames != tc.totalFrames {
+				t.Errorf("TimecodeToFrames(%02d:%02d:%02d;%02d) = %d, expected %d",
+					tc.h, tc.m, tc.s, tc.f, frames, tc.totalFrames)
+			}
+
+			result := FramesToTimecode(tc.totalFrames, format)
+			if result.Hours != tc.h || result.Minutes != tc.m || result.Seconds != tc.s || result.Frames != tc.f {
+				t.Errorf("FramesToTimecode(%d) = %s, expected %02d:%02d:%02d:%02d",
+					tc.totalFrames, result.Timecode, tc.h, tc.m, tc.s, tc.f)
+			}
+		})
+	}
+}
+
+// TestDropFrameRoundTrip converts every frame across 24 hours to a timecode and back
+func TestDropFrameRoundTrip(t *testing.T) {
+	for _, format := range FPSFormats {
+		if !format.DropFrame {
+			continue
+		}
+		t.Run(format.Name, func(t *testing.T) {
+			frameRate := format.NominalRate()
+			dropFrames := format.DroppedFramesPerMinute()
+			framesPer24Hours := TimecodeToFrames(24, 0, 0, 0, format)
+
+			prev := TimecodeResult{Frames: -1}
+			for n := 0; n < framesPer24Hours; n++ {
+				result := FramesToTimecode(n, format)
+
+				if back := TimecodeToFrames(result.Hours, result.Minutes, result.Seconds, result.Frames, format); back != n {
+					t.Fatalf("frame %d -> %s -> %d", n, result.Timecode, back)
+				}
+
+				if result.Frames < 0 || result.Frames >= frameRate || result.Seconds >= 60 || result.Minutes >= 60 || result.Hours >= 24 {
+					t.Fatalf("frame %d produced out-of-range label %s", n, result.Timecode)
+				}
+
+				// Dropped labels must never be produced
+				if result.Seconds == 0 && result.Minutes%10 != 0 && result.Frames < dropFrames {
+					t.Fatalf("frame %d produced dropped label %s", n, result.Timecode)
+				}
+
+				// Labels must advance by exactly one frame, or skip the dropped labels at a minute boundary
+				if n > 0 {
+					expectedFrame := prev.Frames + 1
+					if expectedFrame == frameRate {
+						expectedFrame = 0
+						if prev.Seconds == 59 && (prev.Minutes+1)%10 != 0 {
+							expectedFrame = dropFrames
+						}
+					}
+					if result.Frames != expectedFrame {
+						t.Fatalf("frame %d: label %s does not follow %s", n, result.Timecode, prev.Timecode)
+					}
+				}
+				prev = result
+			}
+		})
+	}
+}
+
+// TestValidateTimecode checks detection and normalization of illegal labels
+func TestValidateTimecode(t *testing.T) {
+	testCases := []struct {
+		name       string
+		formatName string
+		h, m, s, f int
+		valid      bool
+		suggestion string
+	}{
+		{"29.97 df dropped frame 00", "29.97 fps (df)", 0, 1, 0, 0, false, "00:01:00:02"},
+		{"29.97 df dropped frame 01", "29.97 fps (df)", 0, 1, 0, 1, false, "00:01:00:02"},
+		{"29.97 df first legal frame", "29.97 fps (df)", 0, 1, 0, 2, true, "00:01:00:02"},
+		{"29.97 df tenth minute keeps frame 00", "29.97 fps (df)", 0, 10, 0, 0, true, "00:10:00:00"},
+		{"59.94 df dropped frame 03", "59.94 fps (df)", 1, 23, 0, 3, false, "01:23:00:04"},
+		{"29.97 ndf keeps frame 00", "29.97 fps", 0, 1, 0, 0, true, "00:01:00:00"},
+		{"25 fps frames out of range", "25 fps", 0, 0, 10, 25, false, "00:00:10:24"},
+		{"30 fps seconds out of range", "30 fps", 0, 0, 75, 0, false, "00:00:59:00"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validation := ValidateTimecode(tc.h, tc.m, tc.s, tc.f, GetFPSFormat(tc.formatName))
+			if validation.Valid != tc.valid {
+				t.Errorf("Valid = %v, expected %v (%s)", validation.Valid, tc.valid, validation.Message)
+			}
+			if validation.Suggestion.Timecode != tc.suggestion {
+				t.Errorf("Suggestion = %s, expected %s", validation.Suggestion.Timecode, tc.suggestion)
+			}
+		})
+	}
+}
+
+// TestSubtractTimecodesResultModes checks signed results and 24h wraparound
+func TestSubtractTimecodesResultModes(t *testing.T) {
+	testCases := []struct {
+		name       string
+		formatName string
+		mode       ResultMode
+		tc1, tc2   [4]int
+		expected   string
+		frames     int
+	}{
+		{"signed negative", "25 fps", ResultSigned, [4]int{0, 0, 0, 0}, [4]int{0, 0, 3, 12}, "-00:00:03:12", -87},
+		{"signed positive", "25 fps", ResultSigned, [4]int{0, 0, 3, 12}, [4]int{0, 0, 0, 0}, "00:00:03:12", 87},
+		{"wrap before midnight", "25 fps", ResultWrap24h, [4]int{0, 0, 0, 0}, [4]int{0, 0, 3, 12}, "23:59:56:13", 2159913},
+		{"wrap drop-frame", "29.97 fps (df)", ResultWrap24h, [4]int{0, 0, 0, 0}, [4]int{0, 0, 0, 1}, "23:59:59:29", 2589407},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := SubtractTimecodes(tc.tc1[0], tc.tc1[1], tc.tc1[2], tc.tc1[3],
+				tc.tc2[0], tc.tc2[1], tc.tc2[2], tc.tc2[3], GetFPSFormat(tc.formatName), tc.mode)
+			if result.Timecode != tc.expected || result.TotalFrames != tc.frames {
+				t.Errorf("got %s (%df), expected %s (%df)", result.Timecode, result.TotalFrames, tc.expected, tc.frames)
+			}
+		})
+	}
+
+	// Wrapping forward past midnight
+	result := AddTimecodes(23, 59, 59, 24, 0, 0, 0, 2, GetFPSFormat("25 fps"), ResultWrap24h)
+	if result.Timecode != "00:00:00:01" {
+		t.Errorf("23:59:59:24 + 00:00:00:02 wrapped to %s, expected 00:00:00:01", result.Timecode)
+	}
+}
+
+// TestHighFrameRateTimecode checks three-digit frame labels above 99 fps
+func TestHighFrameRateTimecode(t *testing.T) {
+	testCases := []struct {
+		formatName  string
+		totalFrames int
+		expected    string
+	}{
+		{"100 fps", 100*3600 + 99, "01:00:00:099"},
+		{"119.88 fps", 120*60 + 105, "00:01:00:105"},
+		{"120 fps", 119, "00:00:00:119"},
+		{"96 fps", 96*10 + 95, "00:00:10:95"},
+		{"47.952 fps", 48 + 47, "00:00:01:47"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.formatName, func(t *testing.T) {
+			format := GetFPSFormat(tc.formatName)
+			if format.Name != tc.formatName {
+				t.Fatalf("format %q not found", tc.formatName)
+			}
+
+			result := FramesToTimecode(tc.totalFrames, format)
+			if result.Timecode != tc.expected {
+				t.Errorf("FramesToTimecode(%d) = %s, expected %s", tc.totalFrames, result.Timecode, tc.expected)
+			}
+			if back := TimecodeToFrames(result.Hours, result.Minutes, result.Seconds, result.Frames, format); back != tc.totalFrames {
+				t.Errorf("round trip of %s gave %d, expected %d", result.Timecode, back, tc.totalFrames)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+package logic
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ExpressionResult holds the value of a timecode expression
+type ExpressionResult struct {
+	IsTimecode bool
+	Timecode   TimecodeResult // Result when the expression evaluates to a duration
+	Scalar     float64        // Result when the expression evaluates to a plain number (e.g. tc / tc)
+}
+
+// expressionValue is an intermediate value: a duration in (possibly fractional) frames or a plain number
+type expressionValue struct {
+	value      float64
+	isTimecode bool
+}
+
+// expressionParser is a recursive descent parser over a tokenized expression
+type expressionParser struct {
+	tokens []string
+	pos    int
+	format FPSFormat
+}
+
+// EvaluateTimecodeExpression evaluates expressions such as
+// "01:00:00:00 + 3 * 00:00:10:12 - 00:00:00:05" or "00:10:00:00 / 7".
+// Timecodes can be added and subtracted, multiplied and divided by numbers,
+// and divided by each other to get a ratio. Fractional frames from divisions
+// are kept until the end and rounded to the nearest frame.
+func EvaluateTimecodeExpression(expr string, format FPSFormat, mode ResultMode) (ExpressionResult, error) {
+	tokens, err := tokenizeExpression(expr)
+	if err != nil {
+		return ExpressionResult{}, err
+	}
+	if len(tokens) == 0 {
+		return ExpressionResult{}, errors.New("empty expression")
+	}
+
+	p := &expressionParser{tokens: tokens, format: format}
+	v, err := p.parseSum()
+	if err != nil {
+		return ExpressionResult{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return ExpressionResult{}, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+
+	if !v.isTimecode {
+		return ExpressionResult{Scalar: v.value}, nil
+	}
+
+	totalFrames := int(math.Round(v.value))
+	return ExpressionResult{
+		IsTimecode: true,
+		Timecode:   FramesToTimecode(ApplyResultMode(totalFrames, format, mode), format),
+	}, nil
+}
+
+// tokenizeExpression splits an expression into operators, parentheses and operands.
+// Operands are runs of digits and separators; anything with ':' or ';', or more than
+// one '.', is a timecode and everything else is a number.
+func tokenizeExpression(expr string) ([]string, error) {
+	var tokens []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t':
+			i++
+		case strings.ContainsRune("+-*/()x×÷", r):
+			// Accept x, × and ÷ as they are commonly typed for multiply and divide
+			switch r {
+			case 'x', '×':
+				r = '*'
+			case '÷':
+				r = '/'
+			}
+			tokens = append(tokens, string(r))
+			i++
+		case (r >= '0' && r <= '9') || r == ':' || r == ';' || r == '.' || r == ',':
+			start := i
+			for i < len(runes) && ((runes[i] >= '0' && runes[i] <= '9') || strings.ContainsRune(":;.,", runes[i])) {
+				i++
+			}
+			tokens = append(tokens, string(runes[start:i]))
+		default:
+			return nil, fmt.Errorf("unexpected character %q", r)
+		}
+	}
+	return tokens, nil
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos]
+	}
+	return ""
+}
+
+// parseSum handles '+' and '-' between terms
+func (p *expressionParser) parseSum() (expressionValue, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return left, err
+	}
+	for p.peek() == "+" || p.peek() == "-" {
+		op := p.tokens[p.pos]
+		p.pos++
+		right, err := p.parseProduct()
+		if err != nil {
+			return right, err
+		}
+		if left.isTimecode != right.isTimecode {
+			return left, errors.New("cannot mix timecodes and numbers in + or -, write frames as a timecode (e.g. 00:00:00:05)")
+		}
+		if op == "+" {
+			left.value += right.value
+		} else {
+			left.value -= right.value
+		}
+	}
+	return left, nil
+}
+
+// parseProduct handles '*' and '/' between factors
+func (p *expressionParser) parseProduct() (expressionValue, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return left, err
+	}
+	for p.peek() == "*" || p.peek() == "/" {
+		op := p.tokens[p.pos]
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return right, err
+		}
+
+		if op == "*" {
+			if left.isTimecode && right.isTimecode {
+				return left, errors.New("cannot multiply two timecodes")
+			}
+			left = expressionValue{value: left.value * right.value, isTimecode: left.isTimecode || right.isTimecode}
+			continue
+		}
+
+		if right.value == 0 {
+			return left, errors.New("division by zero")
+		}
+		if !left.isTimecode && right.isTimecode {
+			return left, errors.New("cannot divide a number by a timecode")
+		}
+		// Timecode / timecode gives a ratio, timecode / number a duration
+		left = expressionValue{value: left.value / right.value, isTimecode: left.isTimecode && !right.isTimecode}
+	}
+	return left, nil
+}
+
+// parseUnary handles a leading minus sign
+func (p *expressionParser) parseUnary() (expressionValue, error) {
+	if p.peek() == "-" {
+		p.pos++
+		v, err := p.parseUnary()
+		v.value = -v.value
+		return v, err
+	}
+	if p.peek() == "+" {
+		p.pos++
+		return p.parseUnary()
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary handles operands and parenthesized sub-expressions
+func (p *expressionParser) parsePrimary() (expressionValue, error) {
+	token := p.peek()
+	if token == "" {
+		return expressionValue{}, errors.New("unexpected end of expression")
+	}
+	p.pos++
+
+	if token == "(" {
+		v, err := p.parseSum()
+		if err != nil {
+			return v, err
+		}
+		if p.peek() != ")" {
+			return v, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return v, nil
+	}
+
+	if strings.ContainsAny(token, ":;") || strings.Count(token, ".") > 1 {
+		frames, err := ParseTimecode(token, p.format)
+		if err != nil {
+			return expressionValue{}, err
+		}
+		return expressionValue{value: float64(frames), isTimecode: true}, nil
+	}
+
+	if strings.ContainsAny(token, "+-*/)") {
+		return expressionValue{}, fmt.Errorf("unexpected %q", token)
+	}
+	number, err := strconv.ParseFloat(strings.ReplaceAll(token, ",", "."), 64)
+	if err != nil {
+		return expressionValue{}, fmt.Errorf("invalid number %q", token)
+	}
+	return expressionValue{value: number}, nil
+}
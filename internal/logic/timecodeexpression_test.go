@@ -0,0 +1,65 @@
+package logic
+
+import "testing"
+
+// TestEvaluateTimecodeExpression checks operator precedence, scalars and ratios
+func TestEvaluateTimecodeExpression(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expr       string
+		formatName string
+		expected   string
+		scalar     float64
+	}{
+		{"precedence", "01:00:00:00 + 3 * 00:00:10:12 - 00:00:00:05", "25 fps", "01:00:31:06", 0},
+		{"split into equal parts", "00:00:07:00 / 7", "25 fps", "00:00:01:00", 0},
+		{"rounds to nearest frame", "00:00:01:00 / 3", "25 fps", "00:00:00:08", 0},
+		{"parentheses", "(00:00:10:00 - 00:00:04:00) * 2", "30 fps", "00:00:12:00", 0},
+		{"negative result", "00:00:01:00 - 00:00:02:00", "30 fps", "-00:00:01:00", 0},
+		{"drop-frame with semicolons", "00:00:59;29 + 00:00:00;01", "29.97 fps (df)", "00:01:00:02", 0},
+		{"dot separators", "01.00.00.00 - 00.59.59.24", "25 fps", "00:00:00:01", 0},
+		{"number times timecode", "2 x 00:00:00:12", "24 fps", "00:00:01:00", 0},
+		{"ratio of two timecodes", "00:01:00:00 / 00:00:30:00", "25 fps", "", 2},
+		{"decimal scalar", "00:00:10:00 * 1.5", "25 fps", "00:00:15:00", 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := EvaluateTimecodeExpression(tc.expr, GetFPSFormat(tc.formatName), ResultSigned)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.expected == "" {
+				if result.IsTimecode || result.Scalar != tc.scalar {
+					t.Errorf("got %+v, expected scalar %v", result, tc.scalar)
+				}
+				return
+			}
+			if !result.IsTimecode || result.Timecode.Timecode != tc.expected {
+				t.Errorf("got %s, expected %s", result.Timecode.Timecode, tc.expected)
+			}
+		})
+	}
+}
+
+// TestEvaluateTimecodeExpressionErrors checks that malformed expressions are rejected
+func TestEvaluateTimecodeExpressionErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"00:00:01:00 + 5",
+		"00:00:01:00 * 00:00:01:00",
+		"5 / 00:00:01:00",
+		"00:00:01:00 / 0",
+		"(00:00:01:00",
+		"00:00:01:00 +",
+		"00:01:00;00",
+		"00:00:01:00 ? 2",
+	}
+
+	format := GetFPSFormat("29.97 fps (df)")
+	for _, expr := range invalid {
+		if _, err := EvaluateTimecodeExpression(expr, format, ResultSigned); err == nil {
+			t.Errorf("expected error for %q", expr)
+		}
+	}
+}
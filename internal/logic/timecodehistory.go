@@ -0,0 +1,109 @@
+package logic
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+)
+
+// HistoryKind tells what produced a timecode history entry
+type HistoryKind string
+
+const (
+	HistoryOperation  HistoryKind = "operation"  // Timecode 1 +/- Timecode 2
+	HistoryExpression HistoryKind = "expression" // Evaluated expression
+	HistoryConversion HistoryKind = "conversion" // Timecode carried over to another frame rate
+	HistoryReport     HistoryKind = "report"     // Report from a tool (EDL, LTC, MTC, ...)
+)
+
+// HistoryLimit caps the number of persisted history entries, oldest are dropped first
+const HistoryLimit = 500
+
+// HistoryEntry is one calculation in the timecode history.
+// Counts are in Unit, "frames" or "samples"; entries without counts leave Unit empty.
+type HistoryEntry struct {
+	Time          time.Time   `json:"time"`
+	Kind          HistoryKind `json:"kind"`
+	FPS           string      `json:"fps,omitempty"`
+	SourceFPS     string      `json:"sourceFps,omitempty"` // Frame rate before a conversion
+	Mode          string      `json:"mode,omitempty"`      // Result mode, e.g. "24h Wrap"
+	SampleRate    int         `json:"sampleRate,omitempty"`
+	Unit          string      `json:"unit,omitempty"`
+	Operand1      string      `json:"operand1,omitempty"` // Timecode, or the expression text
+	Operand1Count int64       `json:"operand1Count"`
+	Operator      string      `json:"operator,omitempty"`
+	Operand2      string      `json:"operand2,omitempty"`
+	Operand2Count int64       `json:"operand2Count"`
+	Result        string      `json:"result,omitempty"`
+	ResultCount   int64       `json:"resultCount"`
+	Footage       string      `json:"footage,omitempty"` // Result in feet+frames, with the film format
+	Text          string      `json:"text"`              // Entry as shown in the history
+}
+
+// TrimHistory keeps the newest HistoryLimit entries
+func TrimHistory(entries []HistoryEntry) []HistoryEntry {
+	if len(entries) > HistoryLimit {
+		return entries[len(entries)-HistoryLimit:]
+	}
+	return entries
+}
+
+// WriteHistoryJSON writes the entries as an indented JSON array, oldest first
+func WriteHistoryJSON(w io.Writer, entries []HistoryEntry) error {
+	if entries == nil {
+		entries = []HistoryEntry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// ReadHistoryJSON reads entries written by WriteHistoryJSON
+func ReadHistoryJSON(r io.Reader) ([]HistoryEntry, error) {
+	var entries []HistoryEntry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// WriteHistoryCSV writes the entries as CSV with a header row, oldest first
+func WriteHistoryCSV(w io.Writer, entries []HistoryEntry) error {
+	cw := csv.NewWriter(w)
+	header := []string{"time", "kind", "fps", "source_fps", "mode", "sample_rate", "unit",
+		"operand1", "operand1_count", "operator", "operand2", "operand2_count",
+		"result", "result_count", "footage", "text"}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		// Counts are left empty where there's no timecode to count
+		count := func(value int64, operand string) string {
+			if e.Unit == "" || operand == "" {
+				return ""
+			}
+			return strconv.FormatInt(value, 10)
+		}
+		operand1Count := count(e.Operand1Count, e.Operand1)
+		if e.Kind == HistoryExpression {
+			operand1Count = ""
+		}
+		sampleRate := ""
+		if e.SampleRate > 0 {
+			sampleRate = strconv.Itoa(e.SampleRate)
+		}
+		record := []string{
+			e.Time.Format(time.RFC3339), string(e.Kind), e.FPS, e.SourceFPS, e.Mode, sampleRate, e.Unit,
+			e.Operand1, operand1Count, e.Operator, e.Operand2, count(e.Operand2Count, e.Operand2),
+			e.Result, count(e.ResultCount, e.Result), e.Footage, e.Text,
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,93 @@
+package logic
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testHistory() []HistoryEntry {
+	at := time.Date(2026, 3, 2, 14, 30, 0, 0, time.UTC)
+	return []HistoryEntry{
+		{
+			Time: at, Kind: HistoryOperation, FPS: "25 fps", Mode: "Signed", Unit: "frames",
+			Operand1: "01:00:00:00", Operand1Count: 90000, Operator: "+",
+			Operand2: "00:00:10:12", Operand2Count: 262,
+			Result: "01:00:10:12", ResultCount: 90262,
+			Text: "  01:00:00:00 (90000f)\n+ 00:00:10:12 (  262f)\n= 01:00:10:12 (90262f) @25",
+		},
+		{
+			Time: at.Add(time.Minute), Kind: HistoryExpression, FPS: "25 fps", Mode: "Signed",
+			Operand1: "00:00:01:00 / 00:00:00:05", Result: "5",
+			Text: "  00:00:01:00 / 00:00:00:05\n= 5",
+		},
+		{
+			Time: at.Add(2 * time.Minute), Kind: HistoryReport, Text: "EDL \"Reel, 1\"",
+		},
+	}
+}
+
+// TestWriteHistoryCSV checks the header, counts and quoting of multi-line text
+func TestWriteHistoryCSV(t *testing.T) {
+	var out bytes.Buffer
+	if err := WriteHistoryCSV(&out, testHistory()); err != nil {
+		t.Fatalf("WriteHistoryCSV failed: %v", err)
+	}
+
+	expected := "time,kind,fps,source_fps,mode,sample_rate,unit,operand1,operand1_count,operator,operand2,operand2_count,result,result_count,footage,text\n" +
+		"2026-03-02T14:30:00Z,operation,25 fps,,Signed,,frames,01:00:00:00,90000,+,00:00:10:12,262,01:00:10:12,90262,,\"  01:00:00:00 (90000f)\n+ 00:00:10:12 (  262f)\n= 01:00:10:12 (90262f) @25\"\n" +
+		"2026-03-02T14:31:00Z,expression,25 fps,,Signed,,,00:00:01:00 / 00:00:00:05,,,,,5,,,\"  00:00:01:00 / 00:00:00:05\n= 5\"\n" +
+		"2026-03-02T14:32:00Z,report,,,,,,,,,,,,,,\"EDL \"\"Reel, 1\"\"\"\n"
+	if out.String() != expected {
+		t.Errorf("CSV =\n%s\nexpected\n%s", out.String(), expected)
+	}
+}
+
+// TestHistoryJSONRoundTrip checks that persisted history reads back unchanged
+func TestHistoryJSONRoundTrip(t *testing.T) {
+	entries := testHistory()
+	var out bytes.Buffer
+	if err := WriteHistoryJSON(&out, entries); err != nil {
+		t.Fatalf("WriteHistoryJSON failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `"operand1Count": 90000`) {
+		t.Errorf("JSON missing frame count:\n%s", out.String())
+	}
+
+	read, err := ReadHistoryJSON(&out)
+	if err != nil {
+		t.Fatalf("ReadHistoryJSON failed: %v", err)
+	}
+	if len(read) != len(entries) {
+		t.Fatalf("read %d entries, expected %d", len(read), len(entries))
+	}
+	for i := range entries {
+		if !read[i].Time.Equal(entries[i].Time) {
+			t.Errorf("entry %d time = %v", i, read[i].Time)
+		}
+		read[i].Time = entries[i].Time
+		if read[i] != entries[i] {
+			t.Errorf("entry %d = %+v, expected %+v", i, read[i], entries[i])
+		}
+	}
+
+	// Empty history is an empty array, not null
+	out.Reset()
+	_ = WriteHistoryJSON(&out, nil)
+	if strings.TrimSpace(out.String()) != "[]" {
+		t.Errorf("empty history = %q", out.String())
+	}
+}
+
+// TestTrimHistory keeps the newest entries
+func TestTrimHistory(t *testing.T) {
+	entries := make([]HistoryEntry, HistoryLimit+3)
+	for i := range entries {
+		entries[i].ResultCount = int64(i)
+	}
+	trimmed := TrimHistory(entries)
+	if len(trimmed) != HistoryLimit || trimmed[0].ResultCount != 3 {
+		t.Errorf("trimmed to %d entries starting at %d", len(trimmed), trimmed[0].ResultCount)
+	}
+}
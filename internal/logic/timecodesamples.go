@@ -0,0 +1,100 @@
+package logic
+
+import (
+	"fmt"
+	"math"
+)
+
+// SampleTimecodeResult holds a position as timecode plus a sample offset into the frame
+type SampleTimecodeResult struct {
+	TimecodeResult       // Frame-accurate part of the position
+	Samples        int   // Samples past the start of the frame
+	SampleRate     int   // Sample rate the offset is counted in
+	TotalSamples   int64 // Position in samples from 00:00:00:00 (negative for signed results)
+	Label          string
+}
+
+// FrameRate returns the exact frame rate as a fraction,
+// e.g. 30000/1001 for 29.97 fps and 25/1 for 25 fps
+func (f FPSFormat) FrameRate() (num, den int64) {
+	if math.Abs(f.FPS-math.Round(f.FPS)) < 1e-9 {
+		return int64(math.Round(f.FPS)), 1
+	}
+	return int64(math.Round(f.FPS * 1001)), 1001
+}
+
+// FrameStartSample returns the sample at which a frame starts
+func FrameStartSample(totalFrames int, format FPSFormat, sampleRate int) int64 {
+	num, den := format.FrameRate()
+	return int64(totalFrames) * int64(sampleRate) * den / num
+}
+
+// TimecodeSamplesToSamples converts a timecode plus sample offset to a position in samples
+func TimecodeSamplesToSamples(hours, minutes, seconds, frames, samples int, format FPSFormat, sampleRate int) int64 {
+	totalFrames := TimecodeToFrames(hours, minutes, seconds, frames, format)
+	return FrameStartSample(totalFrames, format, sampleRate) + int64(samples)
+}
+
+// SamplesToTimecodeSamples converts a position in samples to timecode plus sample offset.
+// Frames at NTSC rates don't hold a whole number of samples, so each frame
+// starts on the last sample at or before its exact start time.
+func SamplesToTimecodeSamples(totalSamples int64, format FPSFormat, sampleRate int) SampleTimecodeResult {
+	result := SampleTimecodeResult{
+		SampleRate:   sampleRate,
+		TotalSamples: totalSamples,
+	}
+	if sampleRate <= 0 {
+		result.TimecodeResult = FramesToTimecode(0, format)
+		result.Label = result.Timecode
+		return result
+	}
+
+	// Negative positions are split into frames and samples by magnitude
+	magnitude := totalSamples
+	if magnitude < 0 {
+		magnitude = -magnitude
+	}
+
+	num, den := format.FrameRate()
+	frames := int(magnitude * num / (int64(sampleRate) * den))
+	for FrameStartSample(frames+1, format, sampleRate) <= magnitude {
+		frames++
+	}
+	for frames > 0 && FrameStartSample(frames, format, sampleRate) > magnitude {
+		frames--
+	}
+
+	result.TimecodeResult = FramesToTimecode(frames, format)
+	if totalSamples < 0 {
+		result.Negative = true
+		result.TotalFrames = -frames
+		result.Timecode = "-" + result.Timecode
+	}
+	result.Samples = int(magnitude - FrameStartSample(frames, format, sampleRate))
+
+	result.Label = fmt.Sprintf("%s + %d smp", result.Timecode, result.Samples)
+	return result
+}
+
+// ApplySampleResultMode wraps a position in samples into a single day in 24h wrap mode
+// and leaves it unchanged in signed mode
+func ApplySampleResultMode(totalSamples int64, format FPSFormat, sampleRate int, mode ResultMode) int64 {
+	if mode != ResultWrap24h {
+		return totalSamples
+	}
+	day := FrameStartSample(FramesPer24Hours(format), format, sampleRate)
+	if day <= 0 {
+		return totalSamples
+	}
+	return ((totalSamples % day) + day) % day
+}
+
+// AddSamplePositions adds two signed positions in samples
+func AddSamplePositions(samples1, samples2 int64, format FPSFormat, sampleRate int, mode ResultMode) SampleTimecodeResult {
+	return SamplesToTimecodeSamples(ApplySampleResultMode(samples1+samples2, format, sampleRate, mode), format, sampleRate)
+}
+
+// SubtractSamplePositions subtracts two signed positions in samples
+func SubtractSamplePositions(samples1, samples2 int64, format FPSFormat, sampleRate int, mode ResultMode) SampleTimecodeResult {
+	return SamplesToTimecodeSamples(ApplySampleResultMode(samples1-samples2, format, sampleRate, mode), format, sampleRate)
+}
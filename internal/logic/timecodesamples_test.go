@@ -0,0 +1,72 @@
+package logic
+
+import "testing"
+
+// TestSampleTimecodeRoundTrip checks timecode + samples conversions at NTSC and integer rates
+func TestSampleTimecodeRoundTrip(t *testing.T) {
+	testCases := []struct {
+		name       string
+		formatName string
+		sampleRate int
+		h, m, s, f int
+		samples    int
+		expected   string
+	}{
+		{"25 fps @ 48k", "25 fps", 48000, 1, 0, 0, 12, 743, "01:00:00:12 + 743 smp"},
+		{"29.97 df @ 48k", "29.97 fps (df)", 48000, 1, 0, 0, 12, 743, "01:00:00:12 + 743 smp"},
+		{"23.976 @ 44.1k", "23.976 fps", 44100, 0, 10, 0, 5, 1838, "00:10:00:05 + 1838 smp"},
+		{"offset carries into next frame", "25 fps", 48000, 0, 0, 0, 0, 1920 + 5, "00:00:00:01 + 5 smp"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			format := GetFPSFormat(tc.formatName)
+			total := TimecodeSamplesToSamples(tc.h, tc.m, tc.s, tc.f, tc.samples, format, tc.sampleRate)
+			result := SamplesToTimecodeSamples(total, format, tc.sampleRate)
+			if result.Label != tc.expected {
+				t.Errorf("got %s, expected %s", result.Label, tc.expected)
+			}
+			if result.TotalSamples != total {
+				t.Errorf("TotalSamples = %d, expected %d", result.TotalSamples, total)
+			}
+		})
+	}
+}
+
+// TestSampleTimecodeFrameBoundaries checks every sample of the first NTSC frames maps back to itself
+func TestSampleTimecodeFrameBoundaries(t *testing.T) {
+	format := GetFPSFormat("29.97 fps")
+	for total := int64(0); total < 48000*2; total++ {
+		result := SamplesToTimecodeSamples(total, format, 48000)
+		back := TimecodeSamplesToSamples(result.Hours, result.Minutes, result.Seconds, result.Frames, result.Samples, format, 48000)
+		if back != total {
+			t.Fatalf("sample %d -> %s -> %d", total, result.Label, back)
+		}
+		// 48000 / 29.97 = 1601.6 samples per frame
+		if result.Samples < 0 || result.Samples > 1601 {
+			t.Fatalf("sample %d has offset %d outside of a frame", total, result.Samples)
+		}
+	}
+}
+
+// TestSubtractSamplePositions checks signed and wrapped sample-accurate subtraction
+func TestSubtractSamplePositions(t *testing.T) {
+	format := GetFPSFormat("25 fps")
+	a := TimecodeSamplesToSamples(0, 0, 0, 0, 0, format, 48000)
+	b := TimecodeSamplesToSamples(0, 0, 0, 1, 100, format, 48000)
+
+	signed := SubtractSamplePositions(a, b, format, 48000, ResultSigned)
+	if signed.Label != "-00:00:00:01 + 100 smp" || signed.TotalSamples != -2020 {
+		t.Errorf("signed result %s (%d smp)", signed.Label, signed.TotalSamples)
+	}
+
+	small := SubtractSamplePositions(a, 100, format, 48000, ResultSigned)
+	if small.Label != "-00:00:00:00 + 100 smp" {
+		t.Errorf("signed sub-frame result %s", small.Label)
+	}
+
+	wrapped := SubtractSamplePositions(a, b, format, 48000, ResultWrap24h)
+	if wrapped.Label != "23:59:59:23 + 1820 smp" {
+		t.Errorf("wrapped result %s", wrapped.Label)
+	}
+}
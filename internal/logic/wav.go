@@ -0,0 +1,198 @@
+package logic
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// WAVWriter streams 16-bit PCM samples into a WAV file.
+// The sample count is fixed up front so the header can be written without seeking.
+type WAVWriter struct {
+	w        *bufio.Writer
+	channels int
+	expected int64 // Sample frames announced in the header
+	written  int64 // Samples written, counted per channel
+}
+
+// NewWAVWriter writes the WAV header for totalSamples sample frames
+func NewWAVWriter(w io.Writer, sampleRate, channels int, totalSamples int64) (*WAVWriter, error) {
+	if sampleRate <= 0 || channels <= 0 || totalSamples < 0 {
+		return nil, errors.New("invalid WAV parameters")
+	}
+	const bytesPerSample = 2
+	dataSize := totalSamples * int64(channels) * bytesPerSample
+	if dataSize > math.MaxUint32-36 {
+		return nil, errors.New("WAV file would exceed 4 GB")
+	}
+
+	bw := bufio.NewWriterSize(w, 64*1024)
+	header := []interface{}{
+		[4]byte{'R', 'I', 'F', 'F'},
+		uint32(36 + dataSize),
+		[4]byte{'W', 'A', 'V', 'E'},
+		[4]byte{'f', 'm', 't', ' '},
+		uint32(16),         // fmt chunk size
+		uint16(1),          // PCM
+		uint16(channels),   // Channels
+		uint32(sampleRate), // Sample rate
+		uint32(sampleRate * channels * bytesPerSample), // Byte rate
+		uint16(channels * bytesPerSample),              // Block align
+		uint16(8 * bytesPerSample),                     // Bits per sample
+		[4]byte{'d', 'a', 't', 'a'},
+		uint32(dataSize),
+	}
+	for _, field := range header {
+		if err := binary.Write(bw, binary.LittleEndian, field); err != nil {
+			return nil, err
+		}
+	}
+
+	return &WAVWriter{w: bw, channels: channels, expected: totalSamples * int64(channels)}, nil
+}
+
+// WriteSample writes one sample in the range -1..1; channels are interleaved
+func (w *WAVWriter) WriteSample(value float64) error {
+	value = math.Max(-1, math.Min(1, value))
+	sample := int16(math.Round(value * math.MaxInt16))
+	w.written++
+	if err := w.w.WriteByte(byte(sample)); err != nil {
+		return err
+	}
+	return w.w.WriteByte(byte(uint16(sample) >> 8))
+}
+
+// Close pads any missing samples with silence so the file matches its header
+func (w *WAVWriter) Close() error {
+	for w.written < w.expected {
+		if err := w.WriteSample(0); err != nil {
+			return err
+		}
+	}
+	return w.w.Flush()
+}
+
+// DBFSToGain converts a level in dBFS to a linear gain
+func DBFSToGain(dbfs float64) float64 {
+	return math.Pow(10, dbfs/20)
+}
+
+// maxWAVFormatChunk is the largest format chunk NewWAVReader accepts
+const maxWAVFormatChunk = 1024
+
+// WAVReader streams samples of the first channel from a PCM or float WAV file
+type WAVReader struct {
+	r             *bufio.Reader
+	SampleRate    int
+	Channels      int
+	BitsPerSample int
+	TotalSamples  int64 // Sample frames in the data chunk
+	float         bool
+	frame         []byte
+	read          int64
+}
+
+// NewWAVReader reads the WAV header up to the start of the sample data
+func NewWAVReader(r io.Reader) (*WAVReader, error) {
+	br := bufio.NewReaderSize(r, 64*1024)
+
+	var riff struct {
+		ID   [4]byte
+		Size uint32
+		Wave [4]byte
+	}
+	if err := binary.Read(br, binary.LittleEndian, &riff); err != nil || string(riff.ID[:]) != "RIFF" || string(riff.Wave[:]) != "WAVE" {
+		return nil, errors.New("not a WAV file")
+	}
+
+	wav := &WAVReader{r: br}
+	haveFormat := false
+	for {
+		var chunk struct {
+			ID   [4]byte
+			Size uint32
+		}
+		if err := binary.Read(br, binary.LittleEndian, &chunk); err != nil {
+			return nil, errors.New("WAV file has no sample data")
+		}
+
+		switch string(chunk.ID[:]) {
+		case "fmt ":
+			// Format chunks are 16 to 40 bytes, anything far bigger is corrupt
+			if chunk.Size < 16 || chunk.Size > maxWAVFormatChunk {
+				return nil, errors.New("invalid WAV format chunk")
+			}
+			body := make([]byte, int64(chunk.Size)+int64(chunk.Size%2))
+			if _, err := io.ReadFull(br, body); err != nil || len(body) < 16 {
+				return nil, errors.New("invalid WAV format chunk")
+			}
+			formatTag := binary.LittleEndian.Uint16(body[0:])
+			wav.Channels = int(binary.LittleEndian.Uint16(body[2:]))
+			wav.SampleRate = int(binary.LittleEndian.Uint32(body[4:]))
+			wav.BitsPerSample = int(binary.LittleEndian.Uint16(body[14:]))
+			if formatTag == 0xFFFE && chunk.Size >= 26 {
+				// WAVE_FORMAT_EXTENSIBLE carries the real format in its sub-format GUID
+				formatTag = binary.LittleEndian.Uint16(body[24:])
+			}
+			switch {
+			case formatTag == 1 && wav.BitsPerSample >= 8 && wav.BitsPerSample <= 32 && wav.BitsPerSample%8 == 0:
+			case formatTag == 3 && (wav.BitsPerSample == 32 || wav.BitsPerSample == 64):
+				wav.float = true
+			default:
+				return nil, errors.New("unsupported WAV sample format")
+			}
+			if wav.Channels <= 0 || wav.SampleRate <= 0 {
+				return nil, errors.New("invalid WAV format chunk")
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("WAV data before format chunk")
+			}
+			wav.frame = make([]byte, wav.Channels*wav.BitsPerSample/8)
+			wav.TotalSamples = int64(chunk.Size) / int64(len(wav.frame))
+			if chunk.Size == 0 || chunk.Size == math.MaxUint32 {
+				// Recorders that were cut off leave the size unset, read to the end
+				wav.TotalSamples = math.MaxInt64
+			}
+			return wav, nil
+		default:
+			if _, err := br.Discard(int(int64(chunk.Size) + int64(chunk.Size%2))); err != nil {
+				return nil, errors.New("WAV file has no sample data")
+			}
+		}
+	}
+}
+
+// ReadSample returns the next sample of the first channel in the range -1..1, or io.EOF
+func (w *WAVReader) ReadSample() (float64, error) {
+	if w.read >= w.TotalSamples {
+		return 0, io.EOF
+	}
+	if _, err := io.ReadFull(w.r, w.frame); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF // Truncated files end at the last whole sample
+		}
+		return 0, err
+	}
+	w.read++
+
+	b := w.frame[:w.BitsPerSample/8]
+	switch {
+	case w.float && w.BitsPerSample == 32:
+		return float64(math.Float32frombits(binary.LittleEndian.Uint32(b))), nil
+	case w.float:
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case w.BitsPerSample == 8:
+		return (float64(b[0]) - 128) / 128, nil // 8-bit WAV is unsigned
+	}
+
+	// Little-endian signed integer, sign-extended from the top byte
+	value := int64(int8(b[len(b)-1]))
+	for i := len(b) - 2; i >= 0; i-- {
+		value = value<<8 | int64(b[i])
+	}
+	return float64(value) / float64(int64(1)<<(w.BitsPerSample-1)), nil
+}
@@ -0,0 +1,36 @@
+package logic
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// wavWithFormatChunk builds a WAV header whose format chunk claims size bytes but holds body
+func wavWithFormatChunk(size uint32, body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(4+8+len(body)))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, size)
+	buf.Write(body)
+	return buf.Bytes()
+}
+
+// TestNewWAVReaderBadFormatChunk rejects oversized and truncated format chunks without panicking
+func TestNewWAVReaderBadFormatChunk(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"size 0xFFFFFFFF", wavWithFormatChunk(0xFFFFFFFF, nil)},
+		{"size 1 MiB", wavWithFormatChunk(1<<20, make([]byte, 16))},
+		{"truncated", wavWithFormatChunk(16, make([]byte, 8))},
+		{"too short", wavWithFormatChunk(8, make([]byte, 8))},
+	}
+	for _, tc := range tests {
+		if _, err := NewWAVReader(bytes.NewReader(tc.data)); err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+	}
+}
@@ -0,0 +1,451 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+func NewBBTTab(session *Session) fyne.CanvasObject {
+	// Constant tempo or a tempo map with ramps and meter changes
+	const constantMode, mapMode = "Constant tempo", "Tempo map"
+	modeSelect := widget.NewSelect([]string{constantMode, mapMode}, nil)
+
+	// Tempo grid: tempo, meter and sequencer resolution
+	tempoEntry := widgets.NewNumericEntry()
+	tempoEntry.PlaceHolder = "BPM"
+
+	const quarterTempo, beatTempo = "♩ = BPM", "Beat = BPM"
+	tempoUnitSelect := widget.NewSelect([]string{quarterTempo, beatTempo}, nil)
+
+	signatureEntry := widget.NewEntry()
+	signatureEntry.PlaceHolder = "4/4"
+	signatureEntry.Validator = func(s string) error {
+		_, err := logic.ParseTimeSignature(s)
+		return err
+	}
+
+	ppqSelect := widget.NewSelectEntry(logic.CommonPPQ)
+	ppqSelect.PlaceHolder = "PPQ"
+
+	// Picture reference: frame rate, sample rate and the timecode of bar 1.
+	// The frame rate is the session's, shared with the timecode tab.
+	fpsFormats := []string{}
+	for _, format := range logic.FPSFormats {
+		fpsFormats = append(fpsFormats, format.Name)
+	}
+	fpsSelect := widget.NewSelect(fpsFormats, nil)
+	fpsSelect.SetSelected(session.Format().Name)
+
+	sampleRateSelect := widget.NewSelectEntry([]string{"44100", "48000", "88200", "96000", "192000"})
+	sampleRateSelect.PlaceHolder = "Sample Rate"
+
+	startEntry := widgets.NewTimecodeEntry(false)
+
+	// Tempo map in its text format, one segment per line
+	mapEntry := widget.NewMultiLineEntry()
+	mapEntry.TextStyle.Monospace = true
+	mapEntry.SetMinRowsVisible(6)
+
+	// Bidirectional position fields
+	bbtEntry := widget.NewEntry()
+	bbtEntry.PlaceHolder = "Bar|Beat|Tick"
+	msEntry := widgets.NewNumericEntry()
+	msEntry.PlaceHolder = "ms from bar 1"
+	samplesEntry := widgets.NewNumericEntry()
+	samplesEntry.PlaceHolder = "Samples from bar 1"
+	timecodeEntry := widgets.NewTimecodeEntry(false)
+
+	// Passage between two positions, e.g. an accelerando
+	fromEntry := widget.NewEntry()
+	fromEntry.PlaceHolder = "From bar|beat|tick"
+	toEntry := widget.NewEntry()
+	toEntry.PlaceHolder = "To bar|beat|tick"
+	passageLabel := widget.NewLabel("")
+	passageLabel.Wrapping = fyne.TextWrapWord
+
+	// Read-only outputs
+	exactLabel := widget.NewLabel("")
+	subFrameLabel := widget.NewLabel("")
+	gridLabel := widget.NewLabel("")
+	gridLabel.Wrapping = fyne.TextWrapWord
+
+	// Flag to prevent circular updates
+	updating := false
+
+	// Constant tempo grid from the tempo, meter and PPQ fields
+	constantGrid := func() (logic.MusicalGrid, error) {
+		signature, err := logic.ParseTimeSignature(signatureEntry.Text)
+		if err != nil {
+			return logic.MusicalGrid{}, err
+		}
+		ppq, _ := strconv.Atoi(strings.TrimSpace(ppqSelect.Text))
+		grid := logic.MusicalGrid{
+			BPM:       logic.ParseFloat(tempoEntry.Text),
+			Signature: signature,
+			PPQ:       ppq,
+			BeatTempo: tempoUnitSelect.Selected == beatTempo,
+		}
+		return grid, grid.Validate()
+	}
+
+	tempoMap := func() (*logic.TempoMap, error) {
+		if modeSelect.Selected == mapMode {
+			return logic.ParseTempoMap(strings.NewReader(mapEntry.Text))
+		}
+		grid, err := constantGrid()
+		if err != nil {
+			return nil, err
+		}
+		return grid.TempoMap(), nil
+	}
+
+	converter := func() (logic.MusicalTimeConverter, error) {
+		format := logic.GetFPSFormat(fpsSelect.Selected)
+		h, m, s, f := startEntry.GetComponents()
+		tempos, err := tempoMap()
+		if err != nil {
+			return logic.MusicalTimeConverter{}, err
+		}
+		sampleRate := logic.ParseSampleRate(sampleRateSelect.Text)
+		if sampleRate <= 0 {
+			return logic.MusicalTimeConverter{}, fmt.Errorf("invalid sample rate")
+		}
+		return logic.MusicalTimeConverter{
+			Map:         tempos,
+			Format:      format,
+			SampleRate:  sampleRate,
+			StartFrames: logic.TimecodeToFrames(h, m, s, f, format),
+		}, nil
+	}
+
+	// Show a position in every field except the one being edited
+	showPosition := func(c logic.MusicalTimeConverter, position logic.MusicalPosition, source fyne.CanvasObject) {
+		if source != bbtEntry {
+			bbtEntry.SetText(position.BBT.String())
+		}
+		if source != msEntry {
+			msEntry.SetText(strconv.FormatFloat(position.Seconds*1000, 'f', 3, 64))
+		}
+		if source != samplesEntry {
+			samplesEntry.SetText(strconv.FormatInt(position.Samples, 10))
+		}
+		if source != timecodeEntry {
+			tc := position.Timecode
+			timecodeEntry.SetComponents(tc.Hours, tc.Minutes, tc.Seconds, tc.Frames)
+			timecodeEntry.SetNegative(tc.Negative)
+		}
+
+		quarters := position.Ticks / float64(c.Map.PPQ)
+		exactLabel.SetText(fmt.Sprintf("%.3f ticks (%.4f ♩)", position.Ticks, quarters))
+		subFrameLabel.SetText(fmt.Sprintf("+%.2f fr (%df)", position.SubFrame, position.Timecode.TotalFrames))
+
+		// Note lengths at the tempo of this position
+		tempo := c.Map.TempoAt(position.Ticks)
+		grid := logic.MusicalGrid{BPM: tempo, Signature: c.Map.SignatureAt(position.BBT.Bar), PPQ: c.Map.PPQ}
+		barMS := grid.TicksToSeconds(float64(grid.TicksPerBar())) * 1000
+		beatMS := grid.TicksToSeconds(float64(grid.TicksPerBeat())) * 1000
+		tickMS := grid.TicksToSeconds(1) * 1000
+		gridLabel.SetText(fmt.Sprintf("♩ = %.3f BPM in %s · Bar %.2f ms · Beat %.2f ms (%d ticks) · Tick %.4f ms",
+			tempo, grid.Signature, barMS, beatMS, grid.TicksPerBeat(), tickMS))
+	}
+
+	// Length of the passage between the From and To positions
+	updatePassage := func(c logic.MusicalTimeConverter) {
+		from, err := logic.ParseBBT(fromEntry.Text)
+		if err != nil {
+			passageLabel.SetText("")
+			return
+		}
+		to, err := logic.ParseBBT(toEntry.Text)
+		if err != nil {
+			passageLabel.SetText("")
+			return
+		}
+		start, err := c.FromBBT(from)
+		if err == nil {
+			var end logic.MusicalPosition
+			end, err = c.FromBBT(to)
+			if err == nil {
+				length := end.Seconds - start.Seconds
+				frames := length * c.Format.FPS
+				passageLabel.SetText(fmt.Sprintf("Length %s (%.3f s, %d smp, %.2f fr)\n%s → %s · ♩ %.2f → %.2f BPM",
+					logic.FormatDuration(length), length, end.Samples-start.Samples, frames,
+					start.Timecode.Timecode, end.Timecode.Timecode, c.Map.TempoAt(start.Ticks), c.Map.TempoAt(end.Ticks)))
+				return
+			}
+		}
+		passageLabel.SetText("⚠ " + err.Error())
+	}
+
+	showError := func(err error) {
+		exactLabel.SetText("⚠ " + err.Error())
+		subFrameLabel.SetText("")
+		gridLabel.SetText("")
+		passageLabel.SetText("")
+	}
+
+	// Convert from whichever field was edited
+	convertFrom := func(source fyne.CanvasObject) {
+		if updating {
+			return
+		}
+		updating = true
+		defer func() { updating = false }()
+
+		c, err := converter()
+		if err != nil {
+			showError(err)
+			return
+		}
+
+		var position logic.MusicalPosition
+		switch source {
+		case msEntry:
+			position = c.FromSeconds(logic.ParseFloat(msEntry.Text) / 1000)
+		case samplesEntry:
+			samples, _ := strconv.ParseInt(strings.TrimSpace(samplesEntry.Text), 10, 64)
+			position = c.FromSamples(samples)
+		case timecodeEntry:
+			h, m, s, f := timecodeEntry.GetComponents()
+			frames := logic.TimecodeToFrames(h, m, s, f, c.Format)
+			if timecodeEntry.IsNegative() {
+				frames = -frames
+			}
+			position = c.FromTimecode(frames)
+		default:
+			b, err := logic.ParseBBT(bbtEntry.Text)
+			if err == nil {
+				position, err = c.FromBBT(b)
+			}
+			if err != nil {
+				showError(err)
+				return
+			}
+		}
+		showPosition(c, position, source)
+		updatePassage(c)
+	}
+
+	bbtEntry.OnChanged = func(string) { convertFrom(bbtEntry) }
+	msEntry.OnChanged = func(string) { convertFrom(msEntry) }
+	samplesEntry.OnChanged = func(string) { convertFrom(samplesEntry) }
+	timecodeEntry.OnChanged = func(string) { convertFrom(timecodeEntry) }
+
+	// Grid changes keep the musical position and move its time
+	recalculate := func() {
+		convertFrom(bbtEntry)
+	}
+	tempoEntry.OnChanged = func(string) { recalculate() }
+	tempoUnitSelect.OnChanged = func(string) { recalculate() }
+	signatureEntry.OnChanged = func(string) { recalculate() }
+	ppqSelect.OnChanged = func(string) { recalculate() }
+	sampleRateSelect.OnChanged = func(string) { recalculate() }
+	startEntry.OnChanged = func(string) { recalculate() }
+	mapEntry.OnChanged = func(string) { recalculate() }
+	fromEntry.OnChanged = func(string) { recalculate() }
+	toEntry.OnChanged = func(string) { recalculate() }
+
+	// Constant tempo rows give way to the tempo map editor in map mode
+	constantRows := container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Tempo"),
+			container.NewGridWithColumns(2, tempoEntry, tempoUnitSelect),
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Time Signature / PPQ"),
+			container.NewGridWithColumns(2, signatureEntry, ppqSelect),
+		),
+	)
+
+	importBtn := widget.NewButton("Import…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if reader == nil {
+				return // Cancelled
+			}
+			defer reader.Close()
+
+			tempos, err := logic.ParseTempoMap(reader)
+			if err != nil {
+				dialog.ShowError(fmt.Errorf("%s: %v", reader.URI().Name(), err), window)
+				return
+			}
+			mapEntry.SetText(tempos.String())
+		}, window)
+		openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".txt"}))
+		openDialog.Show()
+	})
+
+	exportBtn := widget.NewButton("Export…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		tempos, err := logic.ParseTempoMap(strings.NewReader(mapEntry.Text))
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+			defer writer.Close()
+
+			if _, err := writer.Write([]byte(tempos.String())); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+		saveDialog.SetFileName("tempomap.txt")
+		saveDialog.Show()
+	})
+
+	mapRows := container.NewVBox(
+		mapEntry,
+		container.NewGridWithColumns(2, importBtn, exportBtn),
+	)
+	mapRows.Hide()
+
+	// Switching to map mode starts the map from the constant tempo
+	modeSelect.OnChanged = func(mode string) {
+		if mode == mapMode {
+			if strings.TrimSpace(mapEntry.Text) == "" {
+				if grid, err := constantGrid(); err == nil {
+					updating = true
+					mapEntry.SetText(grid.TempoMap().String())
+					updating = false
+				}
+			}
+			constantRows.Hide()
+			mapRows.Show()
+		} else {
+			mapRows.Hide()
+			constantRows.Show()
+		}
+		recalculate()
+	}
+	fpsSelect.OnChanged = func(name string) {
+		threeDigits := logic.GetFPSFormat(name).ThreeDigitFrames()
+		wasUpdating := updating
+		updating = true
+		startEntry.SetThreeDigitFrames(threeDigits)
+		timecodeEntry.SetThreeDigitFrames(threeDigits)
+		updating = wasUpdating
+		_ = session.FPS.Set(name)
+		recalculate()
+	}
+	session.FPS.AddListener(binding.NewDataListener(func() {
+		if name := session.Format().Name; name != fpsSelect.Selected {
+			fpsSelect.SetSelected(name)
+		}
+	}))
+
+	resetToDefaults := func() {
+		updating = true
+		modeSelect.SetSelected(constantMode)
+		mapEntry.SetText("")
+		fromEntry.SetText("1")
+		toEntry.SetText("9")
+		tempoEntry.SetText("120")
+		tempoUnitSelect.SetSelected(quarterTempo)
+		signatureEntry.SetText("4/4")
+		ppqSelect.SetText("960")
+		sampleRateSelect.SetText("48000")
+		startEntry.SetComponents(1, 0, 0, 0)
+		bbtEntry.SetText("1|1|000")
+		updating = false
+		recalculate()
+	}
+
+	resetBtn := widget.NewButton("🔄 Reset", func() {
+		resetToDefaults()
+	})
+
+	clickBtn := widget.NewButton("🥁 Click Track…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		tempos, err := tempoMap()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		showClickTrack(window, tempos)
+	})
+
+	midiBtn := widget.NewButton("🎹 MIDI…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		c, err := converter()
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		showMIDIExport(window, c.Map.Segments[0].StartBPM, c.Map, c.Format, c.StartFrames)
+	})
+
+	resetToDefaults()
+
+	return container.NewVScroll(container.NewVBox(
+		modeSelect,
+		constantRows,
+		mapRows,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Frame Rate"),
+			fpsSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Sample Rate"),
+			sampleRateSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Bar 1 at"),
+			startEntry,
+		),
+		container.NewGridWithColumns(3,
+			clickBtn,
+			midiBtn,
+			resetBtn,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Bars|Beats|Ticks"),
+			bbtEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel(""),
+			exactLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Time (ms)"),
+			msEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Samples"),
+			samplesEntry,
+		),
+		container.NewGridWithColumns(2,
+			timecodeEntry,
+			subFrameLabel,
+		),
+		gridLabel,
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Passage"),
+			container.NewGridWithColumns(2, fromEntry, toEntry),
+		),
+		passageLabel,
+	))
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"musicalc/internal/audio"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showClickTrack renders a metronome for a tempo map, played back or saved as a WAV file
+func showClickTrack(window fyne.Window, tempos *logic.TempoMap) {
+	barsEntry := widgets.NewNumericEntry()
+	barsEntry.SetText("8")
+	subdivisionSelect := widget.NewSelect([]string{"1", "2", "3", "4", "6", "8"}, nil)
+	subdivisionSelect.SetSelected("1")
+	countInSelect := widget.NewSelect([]string{"0", "1", "2", "4"}, nil)
+	countInSelect.SetSelected("1")
+	sampleRateSelect := widget.NewSelectEntry([]string{"44100", "48000", "88200", "96000"})
+	sampleRateSelect.SetText("48000")
+	levelEntry := widgets.NewNumericEntry()
+	levelEntry.SetText("-12")
+
+	infoLabel := widget.NewLabel("")
+	infoLabel.Wrapping = fyne.TextWrapWord
+
+	options := func(sampleRate int) logic.ClickOptions {
+		bars, _ := strconv.Atoi(strings.TrimSpace(barsEntry.Text))
+		subdivision, _ := strconv.Atoi(subdivisionSelect.Selected)
+		countIn, _ := strconv.Atoi(countInSelect.Selected)
+		return logic.ClickOptions{
+			Map:         tempos,
+			Bars:        bars,
+			Subdivision: subdivision,
+			CountIn:     countIn,
+			SampleRate:  sampleRate,
+			LevelDBFS:   -math.Abs(logic.ParseFloat(levelEntry.Text)),
+		}
+	}
+
+	updateInfo := func() {
+		events, length, err := logic.ClickEvents(options(0))
+		if err != nil {
+			infoLabel.SetText("⚠ " + err.Error())
+			return
+		}
+		infoLabel.SetText(fmt.Sprintf("%d clicks · %s (%.3f s)", len(events), logic.FormatDuration(length), length))
+	}
+	barsEntry.OnChanged = func(string) { updateInfo() }
+	subdivisionSelect.OnChanged = func(string) { updateInfo() }
+	countInSelect.OnChanged = func(string) { updateInfo() }
+
+	playBtn := widget.NewButton("▶ Play", func() {
+		track, err := logic.NewClickTrack(options(audio.SampleRate))
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if err := audio.PlayStream(track.Read); err != nil {
+			dialog.ShowError(err, window)
+		}
+	})
+	stopBtn := widget.NewButton("■ Stop", audio.Stop)
+
+	// Long click tracks take a while, so saving renders in the background
+	var saveBtn *widget.Button
+	saveBtn = widget.NewButton("Save WAV…", func() {
+		opts := options(logic.ParseSampleRate(sampleRateSelect.Text))
+		if _, err := logic.NewClickTrack(opts); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+
+			saveBtn.Disable()
+			infoLabel.SetText("Rendering " + writer.URI().Name() + "…")
+			go func() {
+				err := logic.RenderClick(writer, opts)
+				if closeErr := writer.Close(); err == nil {
+					err = closeErr
+				}
+				fyne.Do(func() {
+					saveBtn.Enable()
+					updateInfo()
+					if err != nil {
+						dialog.ShowError(err, window)
+						return
+					}
+					infoLabel.SetText("Saved " + writer.URI().Name())
+				})
+			}()
+		}, window)
+		saveDialog.SetFileName(fmt.Sprintf("Click_%gBPM.wav", tempos.Segments[0].StartBPM))
+		saveDialog.Show()
+	})
+
+	updateInfo()
+
+	content := container.NewVBox(
+		container.NewGridWithColumns(2, widget.NewLabel("Bars"), barsEntry),
+		container.NewGridWithColumns(2, widget.NewLabel("Clicks per Beat"), subdivisionSelect),
+		container.NewGridWithColumns(2, widget.NewLabel("Count-in Bars"), countInSelect),
+		container.NewGridWithColumns(2, widget.NewLabel("Level (dBFS)"), levelEntry),
+		container.NewGridWithColumns(2, widget.NewLabel("WAV Sample Rate"), sampleRateSelect),
+		infoLabel,
+		container.NewGridWithColumns(3, playBtn, stopBtn, saveBtn),
+	)
+
+	clickDialog := dialog.NewCustom("Click Track", "Close", content, window)
+	clickDialog.SetOnClosed(audio.Stop)
+	clickDialog.Resize(fyne.NewSize(400, clickDialog.MinSize().Height))
+	clickDialog.Show()
+}
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+func NewHitPointsTab() fyne.CanvasObject {
+	// Picture reference: frame rate and where the click starts
+	fpsFormats := []string{}
+	for _, format := range logic.FPSFormats {
+		fpsFormats = append(fpsFormats, format.Name)
+	}
+	fpsSelect := widget.NewSelect(fpsFormats, nil)
+	startEntry := widgets.NewTimecodeEntry(false)
+
+	// Hit points, one timecode per line with an optional description
+	hitsEntry := widget.NewMultiLineEntry()
+	hitsEntry.TextStyle.Monospace = true
+	hitsEntry.PlaceHolder = "01:00:04:12  Door slam\n01:00:09:00  Cut to exterior"
+	hitsEntry.SetMinRowsVisible(5)
+
+	// Search range
+	minBPMEntry := widgets.NewNumericEntry()
+	minBPMEntry.PlaceHolder = "Min BPM"
+	maxBPMEntry := widgets.NewNumericEntry()
+	maxBPMEntry.PlaceHolder = "Max BPM"
+	stepSelect := widget.NewSelectEntry([]string{"1", "0.5", "0.25", "0.1", "0.05", "0.01"})
+	stepSelect.PlaceHolder = "Step"
+	offsetEntry := widgets.NewNumericEntry()
+	offsetEntry.PlaceHolder = "± ms (0 = click on start)"
+	beatsEntry := widgets.NewNumericEntry()
+	beatsEntry.PlaceHolder = "Beats per bar"
+
+	// Ranked tempos and where each hit lands for the selected one
+	candidateSelect := widget.NewSelect(nil, nil)
+	candidateSelect.PlaceHolder = "No tempos found"
+	statusLabel := widget.NewLabel("")
+	statusLabel.Wrapping = fyne.TextWrapWord
+	matchesLabel := widget.NewLabel("")
+	matchesLabel.TextStyle.Monospace = true
+
+	// Flag to prevent circular updates
+	updating := false
+
+	var candidates []logic.TempoCandidate
+	var names []string
+	var format logic.FPSFormat
+
+	showCandidate := func(index int) {
+		if index < 0 || index >= len(candidates) {
+			statusLabel.SetText("")
+			matchesLabel.SetText("")
+			return
+		}
+		candidate := candidates[index]
+		statusLabel.SetText(fmt.Sprintf("♩ = %.2f BPM · Click %s · Beat %.3f ms\nFirst click %+.1f ms (%+.2f fr) · Total error %.1f ms · Max %.1f ms",
+			candidate.BPM, candidate.FrameClick, logic.GetTempoData(candidate.BPM, 1).DelayMS,
+			candidate.OffsetMS, candidate.OffsetFrames, candidate.TotalErrorMS, candidate.MaxErrorMS))
+
+		var b strings.Builder
+		for i, match := range candidate.Matches {
+			fmt.Fprintf(&b, "%s  %4d|%d  beat %-4d %+6.2f fr %+7.1f ms  %s\n",
+				logic.FramesToTimecode(match.Hit, format).Timecode, match.Bar, match.BarBeat, match.Beat+1,
+				match.ErrorFrames, match.ErrorMS, names[i])
+		}
+		matchesLabel.SetText(strings.TrimRight(b.String(), "\n"))
+	}
+
+	candidateSelect.OnChanged = func(string) {
+		if updating {
+			return
+		}
+		showCandidate(candidateSelect.SelectedIndex())
+	}
+
+	showError := func(message string) {
+		candidates = nil
+		candidateSelect.Options = nil
+		candidateSelect.ClearSelected()
+		statusLabel.SetText(message)
+		matchesLabel.SetText("")
+	}
+
+	showCandidates := func() {
+		options := make([]string, len(candidates))
+		for i, candidate := range candidates {
+			options[i] = fmt.Sprintf("%d. %.2f BPM (%s) · Σ %.1f ms", i+1, candidate.BPM, candidate.FrameClick, candidate.TotalErrorMS)
+		}
+		candidateSelect.Options = options
+		if len(options) == 0 {
+			showError("No tempos found")
+			return
+		}
+		// The best tempo is shown even when its label hasn't changed
+		updating = true
+		candidateSelect.SetSelectedIndex(0)
+		updating = false
+		candidateSelect.Refresh()
+		showCandidate(0)
+	}
+
+	// Each search gets a number so results of outdated ones are dropped
+	searchID := 0
+
+	calculate := func() {
+		if updating {
+			return
+		}
+		searchID++
+		searchFormat := logic.GetFPSFormat(fpsSelect.Selected)
+		list, err := logic.ParseCueList(hitsEntry.Text, searchFormat)
+		if err != nil {
+			showError("⚠ " + err.Error())
+			return
+		}
+		if len(list.Cues) == 0 {
+			showError("Enter the hit timecodes, one per line")
+			return
+		}
+		hits := make([]int, len(list.Cues))
+		hitNames := make([]string, len(list.Cues))
+		for i, cue := range list.Cues {
+			hits[i], hitNames[i] = cue.Frames, cue.Name
+		}
+
+		h, m, s, f := startEntry.GetComponents()
+		beatsPerBar, _ := strconv.Atoi(strings.TrimSpace(beatsEntry.Text))
+		search := logic.HitPointSearch{
+			Format:      searchFormat,
+			Start:       logic.TimecodeToFrames(h, m, s, f, searchFormat),
+			Hits:        hits,
+			MinBPM:      logic.ParseFloat(minBPMEntry.Text),
+			MaxBPM:      logic.ParseFloat(maxBPMEntry.Text),
+			StepBPM:     logic.ParseFloat(stepSelect.Text),
+			MaxOffsetMS: logic.ParseFloat(offsetEntry.Text),
+			BeatsPerBar: beatsPerBar,
+			Results:     10,
+		}
+
+		// Fine steps over a wide range take a while, so the search runs in the background.
+		// Only the latest search is shown, edits made meanwhile start a new one.
+		id := searchID
+		statusLabel.SetText("Searching…")
+		go func() {
+			found, err := logic.FindHitPointTempos(search)
+			fyne.Do(func() {
+				if id != searchID {
+					return
+				}
+				if err != nil {
+					showError("⚠ " + err.Error())
+					return
+				}
+				candidates, names, format = found, hitNames, searchFormat
+				showCandidates()
+			})
+		}()
+	}
+
+	fpsSelect.OnChanged = func(name string) {
+		startEntry.SetThreeDigitFrames(logic.GetFPSFormat(name).ThreeDigitFrames())
+		calculate()
+	}
+	startEntry.OnChanged = func(string) { calculate() }
+	hitsEntry.OnChanged = func(string) { calculate() }
+	minBPMEntry.OnChanged = func(string) { calculate() }
+	maxBPMEntry.OnChanged = func(string) { calculate() }
+	stepSelect.OnChanged = func(string) { calculate() }
+	offsetEntry.OnChanged = func(string) { calculate() }
+	beatsEntry.OnChanged = func(string) { calculate() }
+
+	resetToDefaults := func() {
+		updating = true
+		fpsSelect.SetSelected("24 fps")
+		startEntry.SetComponents(1, 0, 0, 0)
+		hitsEntry.SetText("")
+		minBPMEntry.SetText("80")
+		maxBPMEntry.SetText("140")
+		stepSelect.SetText("0.01")
+		offsetEntry.SetText("0")
+		beatsEntry.SetText("4")
+		updating = false
+		calculate()
+	}
+
+	resetBtn := widget.NewButton("🔄 Reset", func() {
+		resetToDefaults()
+	})
+
+	resetToDefaults()
+
+	return container.NewVScroll(container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Frame Rate"),
+			fpsSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("First Click at"),
+			startEntry,
+		),
+		widget.NewLabel("Hit Points"),
+		hitsEntry,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("BPM Range"),
+			container.NewGridWithColumns(2, minBPMEntry, maxBPMEntry),
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("BPM Step"),
+			stepSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Click Offset (± ms)"),
+			offsetEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Beats per Bar"),
+			beatsEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel(""),
+			resetBtn,
+		),
+		widget.NewSeparator(),
+		candidateSelect,
+		statusLabel,
+		matchesLabel,
+	))
+}
@@ -7,10 +7,15 @@ import "fyne.io/fyne/v2"
 // This file is also auto-generated by utils/generate-tabicons.py
 var (
 	ResourceAlignmentdelaySvg = resourceAlignmentdelaySvg
+	ResourceBbtSvg = resourceBbtSvg
 	ResourceDelaySvg = resourceDelaySvg
 	ResourceFreq2noteSvg = resourceFreq2noteSvg
+	ResourceHitpointsSvg = resourceHitpointsSvg
+	ResourceMidiclockSvg = resourceMidiclockSvg
 	ResourceNote2freqSvg = resourceNote2freqSvg
+	ResourcePullupdownSvg = resourcePullupdownSvg
 	ResourceSamplelengthSvg = resourceSamplelengthSvg
+	ResourceSwingSvg = resourceSwingSvg
 	ResourceTempochangeSvg = resourceTempochangeSvg
 	ResourceTimecodeSvg = resourceTimecodeSvg
 )
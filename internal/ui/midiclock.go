@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+func NewMIDIClockTab() fyne.CanvasObject {
+	tempoEntry := widgets.NewNumericEntry()
+	tempoEntry.PlaceHolder = "♩ = BPM"
+
+	signatureEntry := widget.NewEntry()
+	signatureEntry.PlaceHolder = "4/4"
+	signatureEntry.Validator = func(s string) error {
+		_, err := logic.ParseTimeSignature(s)
+		return err
+	}
+
+	ppqSelect := widget.NewSelectEntry(logic.CommonPPQ)
+	ppqSelect.PlaceHolder = "PPQ"
+
+	sampleRateSelect := widget.NewSelectEntry([]string{"44100", "48000", "88200", "96000", "192000"})
+	sampleRateSelect.PlaceHolder = "Sample Rate"
+
+	bufferSelect := widget.NewSelectEntry([]string{"32", "64", "128", "256", "512", "1024", "2048"})
+	bufferSelect.PlaceHolder = "Buffer Size"
+
+	// Read-only outputs
+	clockLabel := widget.NewLabel("")
+	clocksLabel := widget.NewLabel("")
+	tickLabel := widget.NewLabel("")
+	ticksLabel := widget.NewLabel("")
+	quantizationLabel := widget.NewLabel("")
+	quantizationLabel.Wrapping = fyne.TextWrapWord
+	bufferLabel := widget.NewLabel("")
+	bufferLabel.Wrapping = fyne.TextWrapWord
+	jitterLabel := widget.NewLabel("")
+	jitterLabel.Wrapping = fyne.TextWrapWord
+
+	// Flag to prevent circular updates
+	updating := false
+
+	clearResults := func(message string) {
+		clockLabel.SetText(message)
+		for _, label := range []*widget.Label{clocksLabel, tickLabel, ticksLabel, quantizationLabel, bufferLabel, jitterLabel} {
+			label.SetText("")
+		}
+	}
+
+	calculate := func() {
+		if updating {
+			return
+		}
+
+		signature, err := logic.ParseTimeSignature(signatureEntry.Text)
+		if err != nil {
+			clearResults("⚠ " + err.Error())
+			return
+		}
+		ppq, _ := strconv.Atoi(strings.TrimSpace(ppqSelect.Text))
+		buffer, _ := strconv.Atoi(strings.TrimSpace(bufferSelect.Text))
+		r, err := logic.CalculateMIDIClock(logic.ParseFloat(tempoEntry.Text), ppq, signature,
+			logic.ParseSampleRate(sampleRateSelect.Text), buffer)
+		if err != nil {
+			clearResults("⚠ " + err.Error())
+			return
+		}
+
+		clockLabel.SetText(fmt.Sprintf("%.4f ms · %.2f smp", r.ClockMS, r.ClockSamples))
+		clocksLabel.SetText(fmt.Sprintf("%g / beat · %g / bar", r.ClocksPerBeat, r.ClocksPerBar))
+		tickLabel.SetText(fmt.Sprintf("%.4f ms · %.3f smp", r.TickMS, r.TickSamples))
+		ticksLabel.SetText(fmt.Sprintf("%d / beat · %d / bar", r.TicksPerBeat, r.TicksPerBar))
+
+		quantization := fmt.Sprintf("±%.4f ms (±%.3f ticks)", r.QuantizationMS, r.QuantizationTicks)
+		if !r.SampleAccurate {
+			quantization += "\n⚠ Ticks are shorter than a sample"
+		}
+		quantizationLabel.SetText(quantization)
+
+		bufferLabel.SetText(fmt.Sprintf("%.3f ms = %.3f clocks = %.2f ticks", r.BufferMS, r.BufferClocks, r.BufferTicks))
+		if r.JitterMaxBPM > 0 {
+			jitterLabel.SetText(fmt.Sprintf("%.2f – %.2f BPM (buffer = %.1f%% of a clock)", r.JitterMinBPM, r.JitterMaxBPM, r.ClockJitterPercent))
+		} else {
+			jitterLabel.SetText(fmt.Sprintf("⚠ Buffer longer than a clock (%.0f%%), from %.2f BPM", r.ClockJitterPercent, r.JitterMinBPM))
+		}
+	}
+
+	tempoEntry.OnChanged = func(string) { calculate() }
+	signatureEntry.OnChanged = func(string) { calculate() }
+	ppqSelect.OnChanged = func(string) { calculate() }
+	sampleRateSelect.OnChanged = func(string) { calculate() }
+	bufferSelect.OnChanged = func(string) { calculate() }
+
+	resetToDefaults := func() {
+		updating = true
+		tempoEntry.SetText("120")
+		signatureEntry.SetText("4/4")
+		ppqSelect.SetText("960")
+		sampleRateSelect.SetText("48000")
+		bufferSelect.SetText("256")
+		updating = false
+		calculate()
+	}
+
+	resetBtn := widget.NewButton("🔄 Reset", func() {
+		resetToDefaults()
+	})
+
+	resetToDefaults()
+
+	return container.NewVScroll(container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Tempo"),
+			tempoEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Time Signature / PPQ"),
+			container.NewGridWithColumns(2, signatureEntry, ppqSelect),
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Sample Rate"),
+			sampleRateSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Buffer Size"),
+			bufferSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel(""),
+			resetBtn,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("MIDI Clock (24 PPQN)"),
+			clockLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Clocks"),
+			clocksLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Tick"),
+			tickLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Ticks"),
+			ticksLabel,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Sample Quantization"),
+			quantizationLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Buffer Jitter"),
+			bufferLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Clock-to-Clock Tempo"),
+			jitterLabel,
+		),
+	))
+}
@@ -0,0 +1,138 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"musicalc/internal/logic"
+	"slices"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showMIDIExport saves a tempo as a Standard MIDI File with an optional click track.
+// A tempo map, e.g. from the Bars/Beats tab, is exported as is; otherwise the map is
+// built from bpm and the time signature entered in the dialog. The SMPTE offset starts
+// at startFrames in format, or at 01:00:00:00 at 25 fps when a MIDI file can't hold format.
+func showMIDIExport(window fyne.Window, bpm float64, tempos *logic.TempoMap, format logic.FPSFormat, startFrames int) {
+	if tempos == nil && bpm <= 0 {
+		dialog.ShowInformation("Export MIDI", "Enter a tempo first.", window)
+		return
+	}
+
+	signatureEntry := widget.NewEntry()
+	signatureEntry.SetText("4/4")
+	signatureEntry.Validator = func(s string) error {
+		_, err := logic.ParseTimeSignature(s)
+		return err
+	}
+
+	barsEntry := widget.NewEntry()
+	barsEntry.SetText("32")
+	barsEntry.Validator = func(s string) error {
+		if bars, err := strconv.Atoi(strings.TrimSpace(s)); err != nil || bars <= 0 || bars > logic.MIDIFileMaxBars {
+			return fmt.Errorf("enter 1 to %d bars", logic.MIDIFileMaxBars)
+		}
+		return nil
+	}
+
+	// SMPTE offset: only the rates a MIDI file can hold
+	fpsFormats := []string{}
+	for _, f := range logic.FPSFormats {
+		switch f.NominalRate() {
+		case 24, 25, 30:
+			fpsFormats = append(fpsFormats, f.Name)
+		}
+	}
+	if !slices.Contains(fpsFormats, format.Name) {
+		format = logic.GetFPSFormat("25 fps")
+		startFrames = logic.TimecodeToFrames(1, 0, 0, 0, format)
+	}
+	fpsSelect := widget.NewSelect(fpsFormats, nil)
+	fpsSelect.SetSelected(format.Name)
+
+	startEntry := widget.NewEntry()
+	startEntry.SetText(logic.FramesToTimecode(startFrames, format).Timecode)
+	startEntry.Validator = func(s string) error {
+		_, err := logic.ParseTimecode(s, logic.GetFPSFormat(fpsSelect.Selected))
+		return err
+	}
+	fpsSelect.OnChanged = func(string) { startEntry.Validate() }
+
+	offsetCheck := widget.NewCheck("Write SMPTE offset", nil)
+	offsetCheck.SetChecked(true)
+
+	clickCheck := widget.NewCheck("Add click track", nil)
+	clickCheck.SetChecked(true)
+	subdivisionSelect := widget.NewSelect([]string{"1", "2", "3", "4"}, nil)
+	subdivisionSelect.SetSelected("1")
+
+	items := []*widget.FormItem{}
+	title := fmt.Sprintf("Export MIDI @%g BPM", bpm)
+	if tempos == nil {
+		items = append(items, widget.NewFormItem("Time Signature", signatureEntry))
+	} else {
+		title = "Export MIDI Tempo Map"
+	}
+	items = append(items,
+		widget.NewFormItem("Bars", barsEntry),
+		widget.NewFormItem("Frame Rate", fpsSelect),
+		widget.NewFormItem("Bar 1 at", startEntry),
+		widget.NewFormItem("", offsetCheck),
+		widget.NewFormItem("", clickCheck),
+		widget.NewFormItem("Clicks per Beat", subdivisionSelect),
+	)
+
+	form := dialog.NewForm(title, "Save…", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		tempoMap := tempos
+		if tempoMap == nil {
+			signature, _ := logic.ParseTimeSignature(signatureEntry.Text)
+			tempoMap = logic.MusicalGrid{BPM: bpm, Signature: signature, PPQ: 960}.TempoMap()
+		}
+		format := logic.GetFPSFormat(fpsSelect.Selected)
+		startFrames, _ := logic.ParseTimecode(startEntry.Text, format)
+		bars, _ := strconv.Atoi(strings.TrimSpace(barsEntry.Text))
+		subdivision, _ := strconv.Atoi(subdivisionSelect.Selected)
+		opts := logic.MIDIFileOptions{
+			Map:              tempoMap,
+			Bars:             bars,
+			SMPTEOffset:      offsetCheck.Checked,
+			Format:           format,
+			StartFrames:      startFrames,
+			Click:            clickCheck.Checked,
+			ClickSubdivision: subdivision,
+		}
+
+		// Check the file can be written before one is created
+		if err := logic.WriteMIDIFile(io.Discard, opts); err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+			defer writer.Close()
+
+			if err := logic.WriteMIDIFile(writer, opts); err != nil {
+				dialog.ShowError(err, window)
+			}
+		}, window)
+		saveDialog.SetFileName(fmt.Sprintf("Tempo_%gBPM.mid", tempoMap.Segments[0].StartBPM))
+		saveDialog.Show()
+	}, window)
+	form.Resize(fyne.NewSize(400, form.MinSize().Height))
+	form.Show()
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+func NewPullUpDownTab() fyne.CanvasObject {
+	// Preset selector for the usual pull-up, pull-down and PAL transfers
+	const custom = "Custom"
+	presetNames := []string{}
+	for _, change := range logic.SpeedChanges {
+		presetNames = append(presetNames, change.Name)
+	}
+	presetNames = append(presetNames, custom)
+	presetSelect := widget.NewSelect(presetNames, nil)
+
+	fpsFormats := []string{}
+	for _, format := range logic.FPSFormats {
+		fpsFormats = append(fpsFormats, format.Name)
+	}
+	sourceSelect := widget.NewSelect(fpsFormats, nil)
+	targetSelect := widget.NewSelect(fpsFormats, nil)
+
+	// Source length, as a timecode at the source rate or as a real-time duration
+	timecodeEntry := widgets.NewTimecodeEntry(false)
+	framesLabel := widget.NewLabel("")
+	secondsEntry := widgets.NewNumericEntry()
+	secondsEntry.PlaceHolder = "Duration (s)"
+
+	// Read-only outputs
+	sourceDurationLabel := widget.NewLabel("")
+	targetDurationLabel := widget.NewLabel("")
+	targetTimecodeLabel := widget.NewLabel("")
+	speedLabel := widget.NewLabel("")
+	pitchLabel := widget.NewLabel("")
+	semitonesLabel := widget.NewLabel("")
+	semitones50Label := widget.NewLabel("")
+
+	// Flag to prevent circular updates
+	updating := false
+
+	signed := func(format string, value float64) string {
+		text := fmt.Sprintf(format, value)
+		if value > 0 {
+			text = "+" + text
+		}
+		return text
+	}
+
+	sourceFrames := func() int {
+		h, m, s, f := timecodeEntry.GetComponents()
+		return logic.TimecodeToFrames(h, m, s, f, logic.GetFPSFormat(sourceSelect.Selected))
+	}
+
+	updateResults := func() {
+		source := logic.GetFPSFormat(sourceSelect.Selected)
+		target := logic.GetFPSFormat(targetSelect.Selected)
+		res := logic.CalculateSpeedChange(sourceFrames(), source, target)
+
+		framesLabel.SetText(fmt.Sprintf("%d frames", res.Frames))
+		sourceDurationLabel.SetText(logic.FormatDuration(res.SourceSeconds))
+		targetDurationLabel.SetText(fmt.Sprintf("%s (%s s)", logic.FormatDuration(res.TargetSeconds),
+			signed("%.3f", res.TargetSeconds-res.SourceSeconds)))
+		targetTimecodeLabel.SetText(fmt.Sprintf("%s @%s", res.TargetTimecode.Timecode, strings.Split(target.Name, " ")[0]))
+		speedLabel.SetText(fmt.Sprintf("%.4f %% (%s %%)", res.SpeedPercent, signed("%.4f", res.SpeedPercent-100)))
+		pitchLabel.SetText(signed("%.2f", res.PitchCents) + " cents")
+		semitonesLabel.SetText(fmt.Sprintf("%+d st %+d ct", res.Pitch.Semitones, res.Pitch.Cents))
+		semitones50Label.SetText(fmt.Sprintf("%+d st %+d ct", res.Pitch.Semitones50Cent, res.Pitch.Cents50Cent))
+	}
+
+	// Timecode drives the duration field
+	timecodeEntry.OnChanged = func(s string) {
+		if updating {
+			return
+		}
+		updating = true
+		defer func() { updating = false }()
+
+		seconds := logic.FramesToSeconds(sourceFrames(), logic.GetFPSFormat(sourceSelect.Selected))
+		secondsEntry.SetText(fmt.Sprintf("%.3f", seconds))
+		updateResults()
+	}
+
+	// Duration drives the timecode, rounded to the nearest source frame
+	secondsEntry.OnChanged = func(s string) {
+		if updating {
+			return
+		}
+		updating = true
+		defer func() { updating = false }()
+
+		source := logic.GetFPSFormat(sourceSelect.Selected)
+		tc := logic.FramesToTimecode(logic.SecondsToFrames(logic.ParseFloat(s), source), source)
+		timecodeEntry.SetComponents(tc.Hours, tc.Minutes, tc.Seconds, tc.Frames)
+		updateResults()
+	}
+
+	// Pick the preset matching the selected rates, or Custom
+	matchPreset := func() {
+		for _, change := range logic.SpeedChanges {
+			if change.Source == sourceSelect.Selected && change.Target == targetSelect.Selected {
+				presetSelect.SetSelected(change.Name)
+				return
+			}
+		}
+		presetSelect.SetSelected(custom)
+	}
+
+	presetSelect.OnChanged = func(name string) {
+		change, ok := logic.GetSpeedChange(name)
+		if !ok || updating {
+			return
+		}
+		updating = true
+		sourceSelect.SetSelected(change.Source)
+		targetSelect.SetSelected(change.Target)
+		updating = false
+		timecodeEntry.OnChanged(timecodeEntry.Text)
+	}
+
+	sourceSelect.OnChanged = func(name string) {
+		if updating {
+			return
+		}
+		timecodeEntry.SetThreeDigitFrames(logic.GetFPSFormat(name).ThreeDigitFrames())
+		updating = true
+		matchPreset()
+		updating = false
+		// Keep the label, the duration follows the new source rate
+		timecodeEntry.OnChanged(timecodeEntry.Text)
+	}
+	targetSelect.OnChanged = func(name string) {
+		if updating {
+			return
+		}
+		updating = true
+		matchPreset()
+		updating = false
+		updateResults()
+	}
+
+	// Swap source and target, e.g. to undo a transfer
+	swapBtn := widget.NewButton("🔀 Swap", func() {
+		source, target := sourceSelect.Selected, targetSelect.Selected
+		updating = true
+		sourceSelect.SetSelected(target)
+		targetSelect.SetSelected(source)
+		matchPreset()
+		updating = false
+		timecodeEntry.SetThreeDigitFrames(logic.GetFPSFormat(target).ThreeDigitFrames())
+		timecodeEntry.OnChanged(timecodeEntry.Text)
+	})
+
+	resetToDefaults := func() {
+		updating = true
+		presetSelect.SetSelected(logic.SpeedChanges[0].Name)
+		sourceSelect.SetSelected(logic.SpeedChanges[0].Source)
+		targetSelect.SetSelected(logic.SpeedChanges[0].Target)
+		timecodeEntry.SetThreeDigitFrames(false)
+		timecodeEntry.SetComponents(1, 0, 0, 0)
+		updating = false
+		timecodeEntry.OnChanged(timecodeEntry.Text)
+	}
+
+	resetBtn := widget.NewButton("🔄 Reset", func() {
+		resetToDefaults()
+	})
+
+	// Initialize with one hour of film pulled down to 23.976
+	resetToDefaults()
+
+	return container.NewVBox(
+		presetSelect,
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Source Rate"),
+			sourceSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Target Rate"),
+			targetSelect,
+		),
+		container.NewGridWithColumns(2,
+			timecodeEntry,
+			framesLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Duration (s)"),
+			secondsEntry,
+		),
+		container.NewGridWithColumns(2,
+			swapBtn,
+			resetBtn,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Source Duration"),
+			sourceDurationLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("New Duration"),
+			targetDurationLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("New Timecode"),
+			targetTimecodeLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Speed"),
+			speedLabel,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Pitch Change"),
+			pitchLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Transpose"),
+			semitonesLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Transpose (50¢n)"),
+			semitones50Label,
+		),
+	)
+}
@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"musicalc/internal/logic"
+
+	"fyne.io/fyne/v2/data/binding"
+)
+
+// Session is the picture reference the tabs share: the frame rate, set in the timecode
+// and Bars/Beats tabs, and Timecode 1 of the timecode tab. Exports from other tabs, e.g.
+// the SMPTE offset of a MIDI file, start from it so they line up with the session.
+type Session struct {
+	FPS   binding.String // Frame rate name, e.g. "30 fps"
+	Start binding.Int    // Timecode 1 in frames at the session frame rate
+}
+
+// NewSession starts a session at 30 fps, 00:00:00:00
+func NewSession() *Session {
+	session := &Session{FPS: binding.NewString(), Start: binding.NewInt()}
+	_ = session.FPS.Set("30 fps")
+	return session
+}
+
+// Format returns the session frame rate
+func (s *Session) Format() logic.FPSFormat {
+	name, _ := s.FPS.Get()
+	return logic.GetFPSFormat(name)
+}
+
+// StartFrames returns Timecode 1 in frames
+func (s *Session) StartFrames() int {
+	frames, _ := s.Start.Get()
+	return frames
+}
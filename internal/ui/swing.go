@@ -0,0 +1,202 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"musicalc/internal/ui/widgets"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+func NewSwingTab() fyne.CanvasObject {
+	tempoEntry := widgets.NewNumericEntry()
+	tempoEntry.PlaceHolder = "Tempo"
+
+	// Note values that can be swung, straight ones only
+	notes := logic.NoteDivisions(logic.DivisionOptions{Longest: 4, Shortest: 32})
+	noteNames := []string{}
+	for _, note := range notes {
+		noteNames = append(noteNames, note.Name)
+	}
+	noteSelect := widget.NewSelect(noteNames, nil)
+
+	sampleRateSelect := widget.NewSelectEntry([]string{"44100", "48000", "88200", "96000", "192000"})
+	sampleRateSelect.PlaceHolder = "Sample Rate"
+
+	// Bidirectional swing amounts, as taken by different plugins
+	swingEntry := widgets.NewNumericEntry()
+	swingEntry.PlaceHolder = "50–75 %"
+	feelEntry := widgets.NewNumericEntry()
+	feelEntry.PlaceHolder = "0 straight, 100 triplet"
+	offsetEntry := widgets.NewNumericEntry()
+	offsetEntry.PlaceHolder = "Off-beat delay in ms"
+
+	// Read-only outputs
+	onBeatLabel := widget.NewLabel("")
+	offBeatLabel := widget.NewLabel("")
+	offsetLabel := widget.NewLabel("")
+	pairLabel := widget.NewLabel("")
+	pairLabel.Wrapping = fyne.TextWrapWord
+
+	// Flag to prevent circular updates
+	updating := false
+
+	noteMult := func() float64 {
+		for _, note := range notes {
+			if note.Name == noteSelect.Selected {
+				return note.Mult
+			}
+		}
+		return 0.5
+	}
+
+	showError := func(err error) {
+		onBeatLabel.SetText("⚠ " + err.Error())
+		offBeatLabel.SetText("")
+		offsetLabel.SetText("")
+		pairLabel.SetText("")
+	}
+
+	// Recalculate from whichever swing field was edited
+	calculateFrom := func(source fyne.CanvasObject) {
+		if updating {
+			return
+		}
+		updating = true
+		defer func() { updating = false }()
+
+		bpm := logic.ParseFloat(tempoEntry.Text)
+		if bpm <= 0 {
+			showError(fmt.Errorf("tempo must be positive"))
+			return
+		}
+		var percent float64
+		switch source {
+		case feelEntry:
+			percent = logic.SwingFromTripletFeel(logic.ParseFloat(feelEntry.Text))
+		case offsetEntry:
+			percent = logic.SwingFromOffset(bpm, noteMult(), logic.ParseFloat(offsetEntry.Text))
+		default:
+			percent = logic.ParseFloat(swingEntry.Text)
+		}
+
+		r, err := logic.CalculateSwing(bpm, noteMult(), percent, logic.ParseSampleRate(sampleRateSelect.Text))
+		if err != nil {
+			showError(err)
+			return
+		}
+
+		if source != swingEntry {
+			swingEntry.SetText(strconv.FormatFloat(r.Percent, 'f', 2, 64))
+		}
+		if source != feelEntry {
+			feelEntry.SetText(strconv.FormatFloat(r.TripletFeel, 'f', 1, 64))
+		}
+		if source != offsetEntry {
+			offsetEntry.SetText(strconv.FormatFloat(r.OffsetMS, 'f', 3, 64))
+		}
+		onBeatLabel.SetText(fmt.Sprintf("%.3f ms · %.1f smp", r.OnBeatMS, r.OnBeatSamples))
+		offBeatLabel.SetText(fmt.Sprintf("%.3f ms · %.1f smp", r.OffBeatMS, r.OffBeatSamples))
+		offsetLabel.SetText(fmt.Sprintf("%.1f smp", r.OffsetSamples))
+		pairLabel.SetText(fmt.Sprintf("Pair of %s notes: %.3f ms, split %.1f : %.1f", noteSelect.Selected, r.PairMS, r.Percent, 100-r.Percent))
+	}
+
+	swingEntry.OnChanged = func(string) { calculateFrom(swingEntry) }
+	feelEntry.OnChanged = func(string) { calculateFrom(feelEntry) }
+	offsetEntry.OnChanged = func(string) { calculateFrom(offsetEntry) }
+
+	// Tempo and note changes keep the swing amount and move the timing
+	tempoEntry.OnChanged = func(string) { calculateFrom(swingEntry) }
+	noteSelect.OnChanged = func(string) { calculateFrom(swingEntry) }
+	sampleRateSelect.OnChanged = func(string) { calculateFrom(swingEntry) }
+
+	presets := []struct {
+		Name    string
+		Percent float64
+	}{
+		{"Straight", logic.SwingStraight},
+		{"Light 54%", 54},
+		{"Medium 58%", 58},
+		{"Heavy 62%", 62},
+		{"Triplet", logic.SwingTriplet},
+		{"Hard 71%", 71},
+	}
+	presetNames := []string{}
+	for _, preset := range presets {
+		presetNames = append(presetNames, preset.Name)
+	}
+	presetSelect := widget.NewSelect(presetNames, func(name string) {
+		for _, preset := range presets {
+			if preset.Name == name {
+				swingEntry.SetText(strconv.FormatFloat(preset.Percent, 'f', 2, 64))
+			}
+		}
+	})
+	presetSelect.PlaceHolder = "Presets"
+
+	resetToDefaults := func() {
+		updating = true
+		tempoEntry.SetText("120")
+		noteSelect.SetSelected("1/16")
+		sampleRateSelect.SetText("48000")
+		presetSelect.ClearSelected()
+		swingEntry.SetText("58.00")
+		updating = false
+		calculateFrom(swingEntry)
+	}
+
+	resetBtn := widget.NewButton("🔄 Reset", func() {
+		resetToDefaults()
+	})
+
+	resetToDefaults()
+
+	return container.NewVScroll(container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Tempo"),
+			tempoEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Swung Notes"),
+			noteSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Sample Rate"),
+			sampleRateSelect,
+		),
+		container.NewGridWithColumns(2,
+			presetSelect,
+			resetBtn,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Swing (MPC %)"),
+			swingEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Triplet Feel %"),
+			feelEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Off-beat Offset (ms)"),
+			offsetEntry,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Off-beat Offset"),
+			offsetLabel,
+		),
+		widget.NewSeparator(),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("On-beat Delay"),
+			onBeatLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Off-beat Delay"),
+			offBeatLabel,
+		),
+		pairLabel,
+	))
+}
@@ -15,6 +15,13 @@ var resourceAlignmentdelaySvg = &fyne.StaticResource{
 	StaticContent: resourceAlignmentdelaySvgData,
 }
 
+//go:embed bbt.svg
+var resourceBbtSvgData []byte
+var resourceBbtSvg = &fyne.StaticResource{
+	StaticName:    "bbt.svg",
+	StaticContent: resourceBbtSvgData,
+}
+
 //go:embed delay.svg
 var resourceDelaySvgData []byte
 var resourceDelaySvg = &fyne.StaticResource{
@@ -29,6 +36,20 @@ var resourceFreq2noteSvg = &fyne.StaticResource{
 	StaticContent: resourceFreq2noteSvgData,
 }
 
+//go:embed hitpoints.svg
+var resourceHitpointsSvgData []byte
+var resourceHitpointsSvg = &fyne.StaticResource{
+	StaticName:    "hitpoints.svg",
+	StaticContent: resourceHitpointsSvgData,
+}
+
+//go:embed midiclock.svg
+var resourceMidiclockSvgData []byte
+var resourceMidiclockSvg = &fyne.StaticResource{
+	StaticName:    "midiclock.svg",
+	StaticContent: resourceMidiclockSvgData,
+}
+
 //go:embed note2freq.svg
 var resourceNote2freqSvgData []byte
 var resourceNote2freqSvg = &fyne.StaticResource{
@@ -36,6 +57,13 @@ var resourceNote2freqSvg = &fyne.StaticResource{
 	StaticContent: resourceNote2freqSvgData,
 }
 
+//go:embed pullupdown.svg
+var resourcePullupdownSvgData []byte
+var resourcePullupdownSvg = &fyne.StaticResource{
+	StaticName:    "pullupdown.svg",
+	StaticContent: resourcePullupdownSvgData,
+}
+
 //go:embed samplelength.svg
 var resourceSamplelengthSvgData []byte
 var resourceSamplelengthSvg = &fyne.StaticResource{
@@ -43,6 +71,13 @@ var resourceSamplelengthSvg = &fyne.StaticResource{
 	StaticContent: resourceSamplelengthSvgData,
 }
 
+//go:embed swing.svg
+var resourceSwingSvgData []byte
+var resourceSwingSvg = &fyne.StaticResource{
+	StaticName:    "swing.svg",
+	StaticContent: resourceSwingSvgData,
+}
+
 //go:embed tempochange.svg
 var resourceTempochangeSvgData []byte
 var resourceTempochangeSvg = &fyne.StaticResource{
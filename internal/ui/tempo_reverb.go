@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+)
+
+// newReverbSection builds the tempo tab's reverb settings for the tempo in bpm. The returned
+// function recalculates them, e.g. after the sample rate changed.
+func newReverbSection(bpm binding.String, sampleRate *int) (fyne.CanvasObject, func()) {
+	preDelayNames := []string{}
+	for _, note := range logic.ReverbPreDelayDivisions {
+		preDelayNames = append(preDelayNames, note.Name)
+	}
+	tailNames := []string{}
+	for _, note := range logic.ReverbTailDivisions {
+		tailNames = append(tailNames, note.Name)
+	}
+	preDelaySelect := widget.NewSelect(preDelayNames, nil)
+	tailSelect := widget.NewSelect(tailNames, nil)
+
+	// Read-only outputs
+	preDelayLabel := widget.NewLabel("")
+	decayLabel := widget.NewLabel("")
+	tailLabel := widget.NewLabel("")
+	suggestionsLabel := widget.NewLabel("")
+	suggestionsLabel.Wrapping = fyne.TextWrapWord
+
+	multOf := func(notes []logic.NoteDivision, name string) float64 {
+		for _, note := range notes {
+			if note.Name == name {
+				return note.Mult
+			}
+		}
+		return 0
+	}
+
+	calculate := func() {
+		val, _ := bpm.Get()
+		f := logic.ParseFloat(val)
+
+		r, err := logic.CalculateReverb(f, multOf(logic.ReverbPreDelayDivisions, preDelaySelect.Selected),
+			multOf(logic.ReverbTailDivisions, tailSelect.Selected), *sampleRate)
+		if err != nil {
+			preDelayLabel.SetText("⚠ " + err.Error())
+			decayLabel.SetText("")
+			tailLabel.SetText("")
+			suggestionsLabel.SetText("")
+			return
+		}
+		preDelayLabel.SetText(fmt.Sprintf("%.2f ms · %.1f smp", r.PreDelayMS, r.PreDelaySamples))
+		decayLabel.SetText(fmt.Sprintf("%.2f ms · %.1f smp", r.DecayMS, r.DecaySamples))
+		tailLabel.SetText(fmt.Sprintf("%.2f ms · %.1f smp", r.TailMS, r.TailSamples))
+
+		// Decay times for the other tails with the same pre-delay, straight note values only
+		suggestions := []string{}
+		for _, note := range logic.ReverbTailDivisions {
+			if strings.ContainsAny(note.Name, "DT") {
+				continue
+			}
+			other, err := logic.CalculateReverb(f, multOf(logic.ReverbPreDelayDivisions, preDelaySelect.Selected), note.Mult, *sampleRate)
+			if err == nil {
+				suggestions = append(suggestions, fmt.Sprintf("%s: %.0f ms", note.Name, other.DecayMS))
+			}
+		}
+		suggestionsLabel.SetText("Decay to end on " + strings.Join(suggestions, " · "))
+	}
+
+	preDelaySelect.OnChanged = func(string) { calculate() }
+	tailSelect.OnChanged = func(string) { calculate() }
+	bpm.AddListener(binding.NewDataListener(calculate))
+
+	preDelaySelect.SetSelected("1/64")
+	tailSelect.SetSelected("1/2")
+
+	return container.NewVBox(
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Pre-delay"),
+			container.NewGridWithColumns(2, preDelaySelect, preDelayLabel),
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Tail Ends On"),
+			tailSelect,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Decay (RT60)"),
+			decayLabel,
+		),
+		container.NewGridWithColumns(2,
+			widget.NewLabel("Total Tail"),
+			tailLabel,
+		),
+		suggestionsLabel,
+	), calculate
+}
@@ -12,7 +12,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func NewTempoChangeTab() fyne.CanvasObject {
+func NewTempoChangeTab(session *Session) fyne.CanvasObject {
 	// Tempo bindings
 	originalTempo := binding.NewString()
 	_ = originalTempo.Set("120")
@@ -290,6 +290,12 @@ func NewTempoChangeTab() fyne.CanvasObject {
 		newTempoEntry.SetText(origText)
 	})
 
+	// Export the new tempo as a MIDI file
+	midiBtn := widget.NewButton("🎹 MIDI…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		showMIDIExport(window, logic.ParseFloat(newTempoEntry.Text), nil, session.Format(), session.StartFrames())
+	})
+
 	// Reset button
 	resetBtn := widget.NewButton("🔄 Reset", func() {
 		resetToDefaults()
@@ -307,8 +313,9 @@ func NewTempoChangeTab() fyne.CanvasObject {
 			widget.NewLabel("New Tempo"),
 			newTempoEntry,
 		),
-		container.NewGridWithColumns(2,
+		container.NewGridWithColumns(3,
 			swapBtn,
+			midiBtn,
 			resetBtn,
 		),
 		widget.NewSeparator(),
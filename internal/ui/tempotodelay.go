@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"musicalc/internal/logic"
 	"musicalc/internal/ui/widgets"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
@@ -11,7 +12,7 @@ import (
 	"fyne.io/fyne/v2/widget"
 )
 
-func NewTempoTab() fyne.CanvasObject {
+func NewTempoTab(session *Session) fyne.CanvasObject {
 	bpm := binding.NewString()
 	_ = bpm.Set("120")
 	input := widgets.NewNumericEntry()
@@ -21,35 +22,60 @@ func NewTempoTab() fyne.CanvasObject {
 		_ = bpm.Set(s)
 	}
 
-	notes := []struct {
-		Name string
-		Mult float64
-	}{
-		{"1/1", 4.0},
-		{"1/1D", 6.0},
-		{"1/1T", 8.0 / 3.0},
-		{"1/2", 2.0},
-		{"1/2D", 3.0},
-		{"1/2T", 4.0 / 3.0},
-		{"1/4", 1.0},
-		{"1/4D", 1.5},
-		{"1/4T", 2.0 / 3.0},
-		{"1/8", 0.5},
-		{"1/8D", 0.75},
-		{"1/8T", 1.0 / 3.0},
-		{"1/16", 0.25},
-		{"1/16D", 0.375},
-		{"1/16T", 1.0 / 6.0},
-		{"1/32", 0.125},
-		{"1/32D", 0.1875},
-		{"1/32T", 1.0 / 12.0},
-		{"1/64", 0.0625},
-		{"1/64D", 0.09375},
-		{"1/64T", 1.0 / 24.0},
+	// Tap tempo sets the BPM field from the average of the latest taps
+	tapper := logic.NewTapTempo()
+	tapLabel := widget.NewLabel("Tap along to set the tempo")
+	tapLabel.Wrapping = fyne.TextWrapWord
+	tapBtn := widget.NewButton("👆 Tap", func() {
+		result := tapper.Tap(time.Now())
+		if result.BPM == 0 {
+			if result.Taps > 1 {
+				tapLabel.SetText("Uneven taps, keep tapping…")
+			} else {
+				tapLabel.SetText("Keep tapping…")
+			}
+			return
+		}
+		input.SetText(fmt.Sprintf("%.1f", result.BPM))
+		tapLabel.SetText(fmt.Sprintf("%d taps · ±%.1f BPM · %d dropped · %.0f%% confidence",
+			result.Taps, result.SpreadBPM, result.Rejected, result.Confidence*100))
+	})
+	tapResetBtn := widget.NewButton("🔄", func() {
+		tapper.Reset()
+		tapLabel.SetText("Tap along to set the tempo")
+	})
+
+	midiBtn := widget.NewButton("🎹 MIDI…", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		showMIDIExport(window, logic.ParseFloat(input.Text), nil, session.Format(), session.StartFrames())
+	})
+
+	// Sample rate for the samples column
+	sampleRate := 48000
+	sampleRateSelect := widget.NewSelectEntry([]string{"44100", "48000", "88200", "96000", "192000"})
+	sampleRateSelect.SetText("48000")
+	sampleRateSelect.PlaceHolder = "Sample Rate"
+
+	// Note values listed besides the straight ones
+	dottedCheck := widget.NewCheck("Dotted", nil)
+	dottedCheck.SetChecked(true)
+	doubleDottedCheck := widget.NewCheck("Dbl-dotted", nil)
+	tripletsCheck := widget.NewCheck("Triplets", nil)
+	tripletsCheck.SetChecked(true)
+	quintupletsCheck := widget.NewCheck("5:4", nil)
+	septupletsCheck := widget.NewCheck("7:4", nil)
+
+	tupletsEntry := widget.NewEntry()
+	tupletsEntry.PlaceHolder = "n:m, e.g. 6:4, 9:8"
+	tupletsEntry.Validator = func(s string) error {
+		_, err := logic.ParseTuplets(s)
+		return err
 	}
 
+	notes := logic.NoteDivisions(logic.DefaultDivisions)
+
 	table := widget.NewTableWithHeaders(
-		func() (int, int) { return len(notes), 3 },
+		func() (int, int) { return len(notes), 4 },
 		func() fyne.CanvasObject {
 			return widget.NewLabel("")
 		},
@@ -67,6 +93,8 @@ func NewTempoTab() fyne.CanvasObject {
 			case 1:
 				l.SetText(fmt.Sprintf("%.2f ms", res.DelayMS))
 			case 2:
+				l.SetText(fmt.Sprintf("%.1f smp", logic.DelaySamples(res.DelayMS, sampleRate)))
+			case 3:
 				l.SetText(fmt.Sprintf("%.2f Hz", res.ModHz))
 			}
 		},
@@ -93,6 +121,8 @@ func NewTempoTab() fyne.CanvasObject {
 		case 1:
 			l.SetText("Delay")
 		case 2:
+			l.SetText("Samples")
+		case 3:
 			l.SetText("Modulation")
 		}
 	}
@@ -102,16 +132,61 @@ func NewTempoTab() fyne.CanvasObject {
 
 	bpm.AddListener(binding.NewDataListener(func() { table.Refresh() }))
 
+	// Reverb pre-delay and decay at the same tempo, folded away below the table
+	reverbSection, updateReverb := newReverbSection(bpm, &sampleRate)
+	reverbAccordion := widget.NewAccordion(widget.NewAccordionItem("Reverb", reverbSection))
+
+	// Regenerate the rows when the note values or the sample rate change
+	updateDivisions := func() {
+		options := logic.DivisionOptions{
+			Longest:      1,
+			Shortest:     64,
+			Dotted:       dottedCheck.Checked,
+			DoubleDotted: doubleDottedCheck.Checked,
+			Triplets:     tripletsCheck.Checked,
+			Quintuplets:  quintupletsCheck.Checked,
+			Septuplets:   septupletsCheck.Checked,
+		}
+		// Custom tuplets are left out while the entry is invalid
+		if tuplets, err := logic.ParseTuplets(tupletsEntry.Text); err == nil {
+			options.Tuplets = tuplets
+		}
+		if rate := logic.ParseSampleRate(sampleRateSelect.Text); rate > 0 {
+			sampleRate = rate
+		}
+		notes = logic.NoteDivisions(options)
+		table.Refresh()
+		updateReverb()
+	}
+	for _, check := range []*widget.Check{dottedCheck, doubleDottedCheck, tripletsCheck, quintupletsCheck, septupletsCheck} {
+		check.OnChanged = func(bool) { updateDivisions() }
+	}
+	tupletsEntry.OnChanged = func(string) { updateDivisions() }
+	sampleRateSelect.OnChanged = func(string) { updateDivisions() }
+
 	// Wrap table in responsive container with proportional column widths
-	// Proportions: Length (20%), Delay (40%), Modulation (40%)
-	responsiveTableWidget := NewResponsiveTable(table, []float32{0.20, 0.40, 0.40}, 300, 20)
+	// Proportions: Length (22%), Delay (28%), Samples (28%), Modulation (22%)
+	responsiveTableWidget := NewResponsiveTable(table, []float32{0.22, 0.28, 0.28, 0.22}, 300, 20)
 
 	return container.NewBorder(
 		container.NewVBox(
-			input,
+			container.NewBorder(nil, nil, nil, container.NewHBox(tapBtn, tapResetBtn, midiBtn), input),
+			tapLabel,
+			container.NewGridWithColumns(2,
+				widget.NewLabel("Sample Rate"),
+				sampleRateSelect,
+			),
+			container.NewGridWithColumns(3,
+				dottedCheck, doubleDottedCheck, tripletsCheck,
+				quintupletsCheck, septupletsCheck,
+			),
+			container.NewGridWithColumns(2,
+				widget.NewLabel("Custom Tuplets"),
+				tupletsEntry,
+			),
 			widget.NewSeparator(),
 		),
-		nil, nil, nil,
+		reverbAccordion, nil, nil,
 		responsiveTableWidget,
 	)
 }
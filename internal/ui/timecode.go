@@ -1,17 +1,22 @@
 package ui
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"musicalc/internal/logic"
 	"musicalc/internal/ui/widgets"
+	"strconv"
 	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 )
 
-func NewTimecodeTab() fyne.CanvasObject {
+func NewTimecodeTab(session *Session) fyne.CanvasObject {
 	// FPS format selector
 	fpsFormats := []string{}
 	for _, format := range logic.FPSFormats {
@@ -19,7 +24,25 @@ func NewTimecodeTab() fyne.CanvasObject {
 	}
 
 	fpsSelect := widget.NewSelect(fpsFormats, nil)
-	fpsSelect.SetSelected("30 fps")
+	fpsSelect.SetSelected(session.Format().Name)
+
+	// Result mode selector (signed results or 24h wraparound)
+	modeSelect := widget.NewSelect(logic.ResultModeNames, nil)
+	modeSelect.SetSelected("Signed")
+
+	// Sample rate selector for sample-accurate positions (timecode + samples)
+	const framesOnly = "Frames only"
+	sampleRateSelect := widget.NewSelect([]string{framesOnly, "44100", "48000", "88200", "96000", "192000"}, nil)
+	sampleRateSelect.SetSelected(framesOnly)
+
+	// Film format selector for feet+frames display and input
+	const noFootage = "No footage"
+	filmFormats := []string{noFootage}
+	for _, film := range logic.FilmFormats {
+		filmFormats = append(filmFormats, film.Name)
+	}
+	filmSelect := widget.NewSelect(filmFormats, nil)
+	filmSelect.SetSelected(noFootage)
 
 	// First timecode input (single field)
 	timecode1Entry := widgets.NewTimecodeEntry(false)
@@ -27,12 +50,32 @@ func NewTimecodeTab() fyne.CanvasObject {
 	// Output label for first timecode (frame count only)
 	timecode1Label := widget.NewLabel("(0f @ 30)")
 
+	// Sample offset into the first timecode's frame (sample-accurate mode only)
+	samples1Entry := widgets.NewNumericEntry()
+	samples1Entry.PlaceHolder = "Samples"
+
 	// Second timecode input (single field)
 	timecode2Entry := widgets.NewTimecodeEntry(false)
 
 	// Output label for second timecode (frame count only)
 	timecode2Label := widget.NewLabel("(0f @ 30)")
 
+	// Sample offset into the second timecode's frame (sample-accurate mode only)
+	samples2Entry := widgets.NewNumericEntry()
+	samples2Entry.PlaceHolder = "Samples"
+
+	// Footage inputs mirroring each timecode (shown when a film format is selected)
+	footage1Entry := widgets.NewNumericEntry()
+	footage1Entry.PlaceHolder = "Feet+Frames"
+	footage1Label := widget.NewLabel("")
+	footage2Entry := widgets.NewNumericEntry()
+	footage2Entry.PlaceHolder = "Feet+Frames"
+	footage2Label := widget.NewLabel("")
+
+	// Expression field for multi-operand calculations, e.g. 01:00:00:00 + 3 * 00:00:10:12
+	expressionEntry := widget.NewEntry()
+	expressionEntry.PlaceHolder = "Expression, e.g. 01:00:00:00 + 3 * 00:00:10:12"
+
 	// Auto-focus Timecode 2 when Timecode 1 is complete
 	timecode1Entry.OnComplete = func() {
 		fyne.CurrentApp().Driver().CanvasForObject(timecode2Entry).Focus(timecode2Entry)
@@ -42,7 +85,7 @@ func NewTimecodeTab() fyne.CanvasObject {
 	historyText := widget.NewMultiLineEntry()
 	historyText.Wrapping = fyne.TextWrapWord
 	historyText.TextStyle.Monospace = true // Monospace for better alignment
-	historyList := []string{}
+	historyList := []logic.HistoryEntry{}  // Oldest first, shown newest first
 
 	// Create scroll container early so operations can auto-scroll to bottom
 	historyScroll := container.NewVScroll(historyText)
@@ -50,6 +93,97 @@ func NewTimecodeTab() fyne.CanvasObject {
 	// Flag to prevent circular updates
 	updating := false
 
+	// Set while a footage entry drives its timecode, so the footage text isn't rewritten while typing
+	footageEditing := false
+
+	// Signed frame count of an entry (entries only turn negative from signed results)
+	entryFrames := func(entry *widgets.TimecodeEntry, format logic.FPSFormat) int {
+		h, m, s, f := entry.GetComponents()
+		frames := logic.TimecodeToFrames(h, m, s, f, format)
+		if entry.IsNegative() {
+			frames = -frames
+		}
+		return frames
+	}
+
+	// Selected sample rate, 0 when working in whole frames
+	selectedSampleRate := func() int {
+		if sampleRateSelect.Selected == framesOnly {
+			return 0
+		}
+		return logic.ParseSampleRate(sampleRateSelect.Selected)
+	}
+
+	// Signed position of an entry plus its sample offset, in samples
+	entrySamples := func(entry *widgets.TimecodeEntry, samplesEntry *widgets.NumericEntry, format logic.FPSFormat, sampleRate int) int64 {
+		h, m, s, f := entry.GetComponents()
+		offset := int(logic.ParseFloat(samplesEntry.Text))
+		total := logic.TimecodeSamplesToSamples(h, m, s, f, offset, format, sampleRate)
+		if entry.IsNegative() {
+			total = -total
+		}
+		return total
+	}
+
+	// Frame count text for a timecode, flagging labels that don't exist in the selected format
+	frameCountText := func(entry *widgets.TimecodeEntry, samplesEntry *widgets.NumericEntry) string {
+		format := logic.GetFPSFormat(fpsSelect.Selected)
+		h, m, s, f := entry.GetComponents()
+		validation := logic.ValidateTimecode(h, m, s, f, format)
+		if !validation.Valid {
+			return fmt.Sprintf("⚠ invalid, use %s", validation.Suggestion.Timecode)
+		}
+
+		result := logic.FramesToTimecode(entryFrames(entry, format), format)
+
+		fpsLabel := strings.Split(fpsSelect.Selected, " ")[0]
+		if sampleRate := selectedSampleRate(); sampleRate > 0 {
+			totalSamples := entrySamples(entry, samplesEntry, format, sampleRate)
+			return fmt.Sprintf("(%df @ %s, %d smp)", result.TotalFrames, fpsLabel, totalSamples)
+		}
+		return fmt.Sprintf("(%df @ %s)", result.TotalFrames, fpsLabel)
+	}
+
+	// Validate entries against the selected format so illegal labels are flagged on the field
+	timecodeValidator := func(entry *widgets.TimecodeEntry) fyne.StringValidator {
+		return func(string) error {
+			h, m, s, f := entry.GetComponents()
+			validation := logic.ValidateTimecode(h, m, s, f, logic.GetFPSFormat(fpsSelect.Selected))
+			if !validation.Valid {
+				return errors.New(validation.Message)
+			}
+			return nil
+		}
+	}
+	timecode1Entry.Validator = timecodeValidator(timecode1Entry)
+	timecode2Entry.Validator = timecodeValidator(timecode2Entry)
+
+	// Reports whether both entries hold labels that exist in the selected format
+	entriesValid := func() bool {
+		format := logic.GetFPSFormat(fpsSelect.Selected)
+		h1, m1, s1, f1 := timecode1Entry.GetComponents()
+		h2, m2, s2, f2 := timecode2Entry.GetComponents()
+		return logic.ValidateTimecode(h1, m1, s1, f1, format).Valid &&
+			logic.ValidateTimecode(h2, m2, s2, f2, format).Valid
+	}
+
+	// Footage text for a timecode in the selected film format
+	footageText := func(entry *widgets.TimecodeEntry) string {
+		format := logic.GetFPSFormat(fpsSelect.Selected)
+		return logic.FramesToFeet(entryFrames(entry, format), logic.GetFilmFormat(filmSelect.Selected)).Footage
+	}
+
+	// Keep a footage entry in sync with its timecode
+	updateFootage := func(entry *widgets.TimecodeEntry, footageEntry *widgets.NumericEntry, footageLabel *widget.Label) {
+		if filmSelect.Selected == noFootage {
+			return
+		}
+		if !footageEditing {
+			footageEntry.SetText(footageText(entry))
+		}
+		footageLabel.SetText(fmt.Sprintf("(ft+fr @ %s)", filmSelect.Selected))
+	}
+
 	// Calculate first timecode from inputs
 	calculateTimecode1 := func() {
 		if updating {
@@ -58,14 +192,10 @@ func NewTimecodeTab() fyne.CanvasObject {
 		updating = true
 		defer func() { updating = false }()
 
-		h1, m1, s1, f1 := timecode1Entry.GetComponents()
-
-		format := logic.GetFPSFormat(fpsSelect.Selected)
-		totalFrames := logic.TimecodeToFrames(h1, m1, s1, f1, format)
-		result := logic.FramesToTimecode(totalFrames, format)
+		timecode1Label.SetText(frameCountText(timecode1Entry, samples1Entry))
+		updateFootage(timecode1Entry, footage1Entry, footage1Label)
 
-		fpsLabel := strings.Split(fpsSelect.Selected, " ")[0]
-		timecode1Label.SetText(fmt.Sprintf("(%df @ %s)", result.TotalFrames, fpsLabel))
+		_ = session.Start.Set(entryFrames(timecode1Entry, logic.GetFPSFormat(fpsSelect.Selected)))
 	}
 
 	// Calculate second timecode from inputs
@@ -76,18 +206,21 @@ func NewTimecodeTab() fyne.CanvasObject {
 		updating = true
 		defer func() { updating = false }()
 
-		h2, m2, s2, f2 := timecode2Entry.GetComponents()
-
-		format := logic.GetFPSFormat(fpsSelect.Selected)
-		totalFrames := logic.TimecodeToFrames(h2, m2, s2, f2, format)
-		result := logic.FramesToTimecode(totalFrames, format)
+		timecode2Label.SetText(frameCountText(timecode2Entry, samples2Entry))
+		updateFootage(timecode2Entry, footage2Entry, footage2Label)
+	}
 
+	// FPS label for history entries, tagged when results wrap around midnight
+	historyFPSLabel := func() string {
 		fpsLabel := strings.Split(fpsSelect.Selected, " ")[0]
-		timecode2Label.SetText(fmt.Sprintf("(%df @ %s)", result.TotalFrames, fpsLabel))
+		if logic.GetResultMode(modeSelect.Selected) == logic.ResultWrap24h {
+			fpsLabel += " (24h wrap)"
+		}
+		return fpsLabel
 	}
 
-	// Helper function to format history entry with right-aligned frame counts
-	formatHistoryEntry := func(tc1 string, frames1 int, tc2 string, frames2 int, resultTC string, resultFrames int, fpsLabel string, operator string) string {
+	// Helper function to format history entry with right-aligned frame (or sample) counts
+	formatHistoryEntry := func(tc1 string, frames1 int64, tc2 string, frames2 int64, resultTC string, resultFrames int64, unit string, fpsLabel string, operator string) string {
 		// Find the maximum width needed for frame counts
 		frame1Str := fmt.Sprintf("%d", frames1)
 		frame2Str := fmt.Sprintf("%d", frames2)
@@ -102,22 +235,56 @@ func NewTimecodeTab() fyne.CanvasObject {
 		}
 
 		// Format with right-aligned frame counts
-		return fmt.Sprintf("  %s (%*df)\n%s %s (%*df)\n= %s (%*df) @%s",
-			tc1, maxWidth, frames1,
-			operator, tc2, maxWidth, frames2,
-			resultTC, maxWidth, resultFrames, fpsLabel)
+		return fmt.Sprintf("  %s (%*d%s)\n%s %s (%*d%s)\n= %s (%*d%s) @%s",
+			tc1, maxWidth, frames1, unit,
+			operator, tc2, maxWidth, frames2, unit,
+			resultTC, maxWidth, resultFrames, unit, fpsLabel)
+	}
+
+	// History is kept in the app preferences so it survives restarts; the session
+	// itself keeps every entry, only the newest ones are saved
+	const historyPreference = "timecodeHistory"
+	saveHistory := func() {
+		var data bytes.Buffer
+		if err := logic.WriteHistoryJSON(&data, logic.TrimHistory(historyList)); err == nil {
+			fyne.CurrentApp().Preferences().SetString(historyPreference, data.String())
+		}
 	}
 
-	pushHistory := func(entry string) {
-		historyList = append([]string{entry}, historyList...)
-		historyText.SetText(strings.Join(historyList, "\n\n"))
+	showHistory := func() {
+		texts := make([]string, len(historyList))
+		for i, entry := range historyList {
+			texts[len(historyList)-1-i] = entry.Text
+		}
+		historyText.SetText(strings.Join(texts, "\n\n"))
 		historyText.Refresh()
 		historyScroll.ScrollToTop()
 	}
 
+	addHistory := func(entry logic.HistoryEntry) {
+		entry.Time = time.Now()
+		historyList = append(historyList, entry)
+		saveHistory()
+		showHistory()
+	}
+
+	clearHistory := func() {
+		historyList = []logic.HistoryEntry{}
+		saveHistory()
+		historyText.SetText("")
+	}
+
+	// Tool reports are kept as text
+	pushHistory := func(report string) {
+		addHistory(logic.HistoryEntry{Kind: logic.HistoryReport, Text: report})
+	}
+
 	// Track previous FPS for conversion history
 	var previousFPS string
-	previousFPS = "30 fps"
+	previousFPS = fpsSelect.Selected
+
+	// Rate changes from another tab, e.g. Bars/Beats, don't move the focus here
+	fromSession := false
 
 	// Wire up change handlers
 	timecode1Entry.OnChanged = func(s string) {
@@ -128,6 +295,43 @@ func NewTimecodeTab() fyne.CanvasObject {
 		calculateTimecode2()
 	}
 
+	// Footage input sets the timecode (feet+frames at the selected frame rate)
+	applyFootage := func(footageEntry *widgets.NumericEntry, entry *widgets.TimecodeEntry, recalculate func()) {
+		if updating || filmSelect.Selected == noFootage {
+			return
+		}
+		totalFrames, ok := logic.ParseFootage(footageEntry.Text, logic.GetFilmFormat(filmSelect.Selected))
+		if !ok {
+			return
+		}
+		result := logic.FramesToTimecode(totalFrames, logic.GetFPSFormat(fpsSelect.Selected))
+
+		updating = true
+		entry.SetComponents(result.Hours, result.Minutes, result.Seconds, result.Frames)
+		entry.SetNegative(result.Negative)
+		updating = false
+
+		footageEditing = true
+		recalculate()
+		footageEditing = false
+	}
+
+	footage1Entry.OnChanged = func(s string) {
+		applyFootage(footage1Entry, timecode1Entry, calculateTimecode1)
+	}
+
+	footage2Entry.OnChanged = func(s string) {
+		applyFootage(footage2Entry, timecode2Entry, calculateTimecode2)
+	}
+
+	samples1Entry.OnChanged = func(s string) {
+		calculateTimecode1()
+	}
+
+	samples2Entry.OnChanged = func(s string) {
+		calculateTimecode2()
+	}
+
 	fpsSelect.OnChanged = func(s string) {
 		// Only do conversion if FPS actually changed and there's a non-zero timecode
 		if previousFPS != "" && previousFPS != s {
@@ -135,21 +339,23 @@ func NewTimecodeTab() fyne.CanvasObject {
 			h1, m1, s1, f1 := timecode1Entry.GetComponents()
 
 			// Only add conversion history if there's an actual timecode to convert
-			if h1 > 0 || m1 > 0 || s1 > 0 || f1 > 0 {
+			// that also exists in the new format (invalid labels get flagged instead)
+			newValid := logic.ValidateTimecode(h1, m1, s1, f1, logic.GetFPSFormat(s)).Valid
+			if (h1 > 0 || m1 > 0 || s1 > 0 || f1 > 0) && newValid {
 				// Convert from previous FPS to new FPS by preserving TIMECODE NOTATION
 				// MusicMath keeps H:M:S:F constant and recalculates frame count
 				oldFormat := logic.GetFPSFormat(previousFPS)
 				newFormat := logic.GetFPSFormat(s)
 
 				// Get frames in old format
-				oldFrames := logic.TimecodeToFrames(h1, m1, s1, f1, oldFormat)
+				oldFrames := entryFrames(timecode1Entry, oldFormat)
 
 				// Preserve the timecode notation (H:M:S:F) and recalculate frame count with new FPS
 				// The H:M:S:F values stay the same, only total frames changes
-				newFrames := logic.TimecodeToFrames(h1, m1, s1, f1, newFormat)
+				newFrames := entryFrames(timecode1Entry, newFormat)
 
-				oldTC := fmt.Sprintf("%02d:%02d:%02d:%02d", h1, m1, s1, f1)
-				newTC := oldTC // Timecode notation stays the same
+				oldTC := logic.FramesToTimecode(oldFrames, oldFormat).Timecode
+				newTC := logic.FramesToTimecode(newFrames, newFormat).Timecode // Same notation, frame digits follow the new rate
 				oldFpsLabel := strings.Split(previousFPS, " ")[0]
 				newFpsLabel := strings.Split(s, " ")[0]
 
@@ -161,83 +367,223 @@ func NewTimecodeTab() fyne.CanvasObject {
 					maxWidth = len(newFrameStr)
 				}
 
-				conversionEntry := fmt.Sprintf("  %s (%*df) @%s\n= %s (%*df) @%s",
+				entry := logic.HistoryEntry{
+					Kind:          logic.HistoryConversion,
+					FPS:           s,
+					SourceFPS:     previousFPS,
+					Unit:          "frames",
+					Operand1:      oldTC,
+					Operand1Count: int64(oldFrames),
+					Operator:      "convert",
+					Result:        newTC,
+					ResultCount:   int64(newFrames),
+				}
+				entry.Text = fmt.Sprintf("  %s (%*df) @%s\n= %s (%*df) @%s",
 					oldTC, maxWidth, oldFrames, oldFpsLabel, newTC, maxWidth, newFrames, newFpsLabel)
-				pushHistory(conversionEntry)
+
+				// In sample-accurate mode the sample offset stays with the notation,
+				// carrying into the next frame when it no longer fits the new frame length
+				if sampleRate := selectedSampleRate(); sampleRate > 0 {
+					oldSamples := entrySamples(timecode1Entry, samples1Entry, oldFormat, sampleRate)
+					newSamples := entrySamples(timecode1Entry, samples1Entry, newFormat, sampleRate)
+					oldPos := logic.SamplesToTimecodeSamples(oldSamples, oldFormat, sampleRate)
+					newPos := logic.SamplesToTimecodeSamples(newSamples, newFormat, sampleRate)
+					entry.Text = fmt.Sprintf("  %s (%d smp) @%s\n= %s (%d smp) @%s",
+						oldPos.Label, oldSamples, oldFpsLabel, newPos.Label, newSamples, newFpsLabel)
+					entry.SampleRate, entry.Unit = sampleRate, "samples"
+					entry.Operand1, entry.Operand1Count = oldPos.Label, oldSamples
+					entry.Result, entry.ResultCount = newPos.Label, newSamples
+				}
+				addHistory(entry)
 
 				// Timecode H:M:S:F values don't change, only recalculate display with new FPS
 				// No need to update entry fields since H:M:S:F stay the same
 			}
 		}
 		previousFPS = s
+
+		// Rates above 99 fps need three-digit frame entry
+		threeDigits := logic.GetFPSFormat(s).ThreeDigitFrames()
+		updating = true
+		timecode1Entry.SetThreeDigitFrames(threeDigits)
+		timecode2Entry.SetThreeDigitFrames(threeDigits)
+		updating = false
+
 		calculateTimecode1()
 		calculateTimecode2()
+		_ = timecode1Entry.Validate()
+		_ = timecode2Entry.Validate()
+		_ = expressionEntry.Validate()
+
+		_ = session.FPS.Set(s)
 
 		// Focus Timecode 2 for next input after FPS change
-		fyne.CurrentApp().Driver().CanvasForObject(timecode2Entry).Focus(timecode2Entry)
+		if !fromSession {
+			fyne.CurrentApp().Driver().CanvasForObject(timecode2Entry).Focus(timecode2Entry)
+		}
 	}
+	session.FPS.AddListener(binding.NewDataListener(func() {
+		if name := session.Format().Name; name != fpsSelect.Selected {
+			fromSession = true
+			fpsSelect.SetSelected(name)
+			fromSession = false
+		}
+	}))
 
-	// Add operation
-	addButton := widget.NewButton("+", func() {
-		h1, m1, s1, f1 := timecode1Entry.GetComponents()
-		h2, m2, s2, f2 := timecode2Entry.GetComponents()
+	// Add or subtract Timecode 2 from Timecode 1, in whole frames or sample-accurate
+	applyOperation := func(operator string) {
+		// Don't compute with labels that don't exist, the frame labels already flag them
+		if !entriesValid() {
+			return
+		}
 
 		format := logic.GetFPSFormat(fpsSelect.Selected)
-		result := logic.AddTimecodes(h1, m1, s1, f1, h2, m2, s2, f2, format)
+		mode := logic.GetResultMode(modeSelect.Selected)
+
+		var result logic.TimecodeResult
+		resultSamples := ""
+		if sampleRate := selectedSampleRate(); sampleRate > 0 {
+			samples1 := entrySamples(timecode1Entry, samples1Entry, format, sampleRate)
+			samples2 := entrySamples(timecode2Entry, samples2Entry, format, sampleRate)
+			var position logic.SampleTimecodeResult
+			if operator == "+" {
+				position = logic.AddSamplePositions(samples1, samples2, format, sampleRate, mode)
+			} else {
+				position = logic.SubtractSamplePositions(samples1, samples2, format, sampleRate, mode)
+			}
 
-		// Add to history
-		tc1 := fmt.Sprintf("%02d:%02d:%02d:%02d", h1, m1, s1, f1)
-		tc2 := fmt.Sprintf("%02d:%02d:%02d:%02d", h2, m2, s2, f2)
-		frames1 := logic.TimecodeToFrames(h1, m1, s1, f1, format)
-		frames2 := logic.TimecodeToFrames(h2, m2, s2, f2, format)
+			// Add to history
+			tc1 := logic.SamplesToTimecodeSamples(samples1, format, sampleRate).Label
+			tc2 := logic.SamplesToTimecodeSamples(samples2, format, sampleRate).Label
+			addHistory(logic.HistoryEntry{
+				Kind:          logic.HistoryOperation,
+				FPS:           fpsSelect.Selected,
+				Mode:          modeSelect.Selected,
+				SampleRate:    sampleRate,
+				Unit:          "samples",
+				Operand1:      tc1,
+				Operand1Count: samples1,
+				Operator:      operator,
+				Operand2:      tc2,
+				Operand2Count: samples2,
+				Result:        position.Label,
+				ResultCount:   position.TotalSamples,
+				Text:          formatHistoryEntry(tc1, samples1, tc2, samples2, position.Label, position.TotalSamples, " smp", historyFPSLabel(), operator),
+			})
+
+			result = position.TimecodeResult
+			resultSamples = strconv.Itoa(position.Samples)
+		} else {
+			frames1 := entryFrames(timecode1Entry, format)
+			frames2 := entryFrames(timecode2Entry, format)
+			if operator == "+" {
+				result = logic.AddFrames(frames1, frames2, format, mode)
+			} else {
+				result = logic.SubtractFrames(frames1, frames2, format, mode)
+			}
 
-		fpsLabel := strings.Split(fpsSelect.Selected, " ")[0]
-		historyEntry := formatHistoryEntry(tc1, frames1, tc2, frames2, result.Timecode, result.TotalFrames, fpsLabel, "+")
-		pushHistory(historyEntry)
+			// Add to history
+			tc1 := logic.FramesToTimecode(frames1, format).Timecode
+			tc2 := logic.FramesToTimecode(frames2, format).Timecode
+			entry := logic.HistoryEntry{
+				Kind:          logic.HistoryOperation,
+				FPS:           fpsSelect.Selected,
+				Mode:          modeSelect.Selected,
+				Unit:          "frames",
+				Operand1:      tc1,
+				Operand1Count: int64(frames1),
+				Operator:      operator,
+				Operand2:      tc2,
+				Operand2Count: int64(frames2),
+				Result:        result.Timecode,
+				ResultCount:   int64(result.TotalFrames),
+				Text:          formatHistoryEntry(tc1, int64(frames1), tc2, int64(frames2), result.Timecode, int64(result.TotalFrames), "f", historyFPSLabel(), operator),
+			}
+			if filmSelect.Selected != noFootage {
+				footage := logic.FramesToFeet(result.TotalFrames, logic.GetFilmFormat(filmSelect.Selected))
+				entry.Footage = fmt.Sprintf("%s @%s", footage.Footage, filmSelect.Selected)
+				entry.Text += fmt.Sprintf("\n= %s ft+fr @%s", footage.Footage, filmSelect.Selected)
+			}
+			addHistory(entry)
+		}
 
 		// Update first timecode with result and reset second timecode
 		updating = true
 		timecode1Entry.SetComponents(result.Hours, result.Minutes, result.Seconds, result.Frames)
+		timecode1Entry.SetNegative(result.Negative)
+		samples1Entry.SetText(resultSamples)
 		timecode2Entry.SetText("")
+		samples2Entry.SetText("")
 		updating = false
 		calculateTimecode1()
 		calculateTimecode2()
 
 		// Focus Timecode 2 for next input
 		fyne.CurrentApp().Driver().CanvasForObject(timecode2Entry).Focus(timecode2Entry)
+	}
+
+	// Add operation
+	addButton := widget.NewButton("+", func() {
+		applyOperation("+")
 	})
 	addButton.Importance = widget.HighImportance
 
 	// Subtract operation
 	subtractButton := widget.NewButton("-", func() {
-		h1, m1, s1, f1 := timecode1Entry.GetComponents()
-		h2, m2, s2, f2 := timecode2Entry.GetComponents()
+		applyOperation("-")
+	})
+	subtractButton.Importance = widget.HighImportance
 
+	// Validate expressions while typing
+	expressionEntry.Validator = func(text string) error {
+		if strings.TrimSpace(text) == "" {
+			return nil
+		}
+		_, err := logic.EvaluateTimecodeExpression(text, logic.GetFPSFormat(fpsSelect.Selected), logic.GetResultMode(modeSelect.Selected))
+		return err
+	}
+
+	evaluateExpression := func() {
+		expression := strings.TrimSpace(expressionEntry.Text)
+		if expression == "" {
+			return
+		}
 		format := logic.GetFPSFormat(fpsSelect.Selected)
-		result := logic.SubtractTimecodes(h1, m1, s1, f1, h2, m2, s2, f2, format)
+		res, err := logic.EvaluateTimecodeExpression(expression, format, logic.GetResultMode(modeSelect.Selected))
+		if err != nil {
+			expressionEntry.SetValidationError(err)
+			return
+		}
 
-		// Add to history
-		tc1 := fmt.Sprintf("%02d:%02d:%02d:%02d", h1, m1, s1, f1)
-		tc2 := fmt.Sprintf("%02d:%02d:%02d:%02d", h2, m2, s2, f2)
-		frames1 := logic.TimecodeToFrames(h1, m1, s1, f1, format)
-		frames2 := logic.TimecodeToFrames(h2, m2, s2, f2, format)
+		entry := logic.HistoryEntry{
+			Kind:     logic.HistoryExpression,
+			FPS:      fpsSelect.Selected,
+			Mode:     modeSelect.Selected,
+			Operand1: expression,
+		}
+		if !res.IsTimecode {
+			entry.Result = strconv.FormatFloat(res.Scalar, 'f', -1, 64)
+			entry.Text = fmt.Sprintf("  %s\n= %s", expression, entry.Result)
+			addHistory(entry)
+			return
+		}
 
-		fpsLabel := strings.Split(fpsSelect.Selected, " ")[0]
-		historyEntry := formatHistoryEntry(tc1, frames1, tc2, frames2, result.Timecode, result.TotalFrames, fpsLabel, "-")
-		pushHistory(historyEntry)
+		entry.Unit = "frames"
+		entry.Result, entry.ResultCount = res.Timecode.Timecode, int64(res.Timecode.TotalFrames)
+		entry.Text = fmt.Sprintf("  %s\n= %s (%df) @%s", expression, res.Timecode.Timecode, res.Timecode.TotalFrames, historyFPSLabel())
+		addHistory(entry)
 
-		// Update first timecode with result and reset second timecode
+		// Continue calculating with the result, like the +/- operations
 		updating = true
-		timecode1Entry.SetComponents(result.Hours, result.Minutes, result.Seconds, result.Frames)
-		timecode2Entry.SetText("")
+		timecode1Entry.SetComponents(res.Timecode.Hours, res.Timecode.Minutes, res.Timecode.Seconds, res.Timecode.Frames)
+		timecode1Entry.SetNegative(res.Timecode.Negative)
+		samples1Entry.SetText("")
 		updating = false
 		calculateTimecode1()
-		calculateTimecode2()
+	}
+	expressionEntry.OnSubmitted = func(string) { evaluateExpression() }
 
-		// Focus Timecode 2 for next input
-		fyne.CurrentApp().Driver().CanvasForObject(timecode2Entry).Focus(timecode2Entry)
-	})
-	subtractButton.Importance = widget.HighImportance
+	evaluateButton := widget.NewButton("=", evaluateExpression)
 
 	// Keyboard shortcuts for +/- operations
 	handleOperationKey := func(key fyne.KeyName) {
@@ -256,8 +602,11 @@ func NewTimecodeTab() fyne.CanvasObject {
 		updating = true
 		timecode1Entry.SetText("")
 		timecode2Entry.SetText("")
-		historyList = []string{}
-		historyText.SetText("")
+		samples1Entry.SetText("")
+		samples2Entry.SetText("")
+		footage1Entry.SetText("")
+		footage2Entry.SetText("")
+		clearHistory()
 		updating = false
 		calculateTimecode1()
 		calculateTimecode2()
@@ -267,33 +616,124 @@ func NewTimecodeTab() fyne.CanvasObject {
 	})
 
 	// Clear History operation
-	clearHistoryButton := widget.NewButton("Clear Hist.", func() {
-		historyList = []string{}
-		historyText.SetText("")
+	clearHistoryButton := widget.NewButton("Clear Hist.", clearHistory)
+
+	// Tools menu for file-based timecode jobs, reports go to the history
+	var toolsButton *widget.Button
+	toolsButton = widget.NewButton("🧰 Tools", func() {
+		window := fyne.CurrentApp().Driver().AllWindows()[0]
+		menu := fyne.NewMenu("Tools",
+			fyne.NewMenuItem("Import EDL…", func() {
+				showEDLImport(window, logic.GetFPSFormat(fpsSelect.Selected), pushHistory)
+			}),
+			fyne.NewMenuItem("Retime Subtitles…", func() {
+				showSubtitleRetime(window, logic.GetFPSFormat(fpsSelect.Selected), pushHistory)
+			}),
+			fyne.NewMenuItem("Generate LTC…", func() {
+				format := logic.GetFPSFormat(fpsSelect.Selected)
+				start := logic.FramesToTimecode(logic.ApplyResultMode(entryFrames(timecode1Entry, format), format, logic.ResultWrap24h), format)
+				showLTCGenerate(window, format, start.Timecode, pushHistory)
+			}),
+			fyne.NewMenuItem("Read LTC…", func() {
+				showLTCDecode(window, pushHistory)
+			}),
+			fyne.NewMenuItem("MIDI Time Code…", func() {
+				format := logic.GetFPSFormat(fpsSelect.Selected)
+				showMTC(window, format, entryFrames(timecode1Entry, format), pushHistory)
+			}),
+			fyne.NewMenuItem("Cue List…", func() {
+				format := logic.GetFPSFormat(fpsSelect.Selected)
+				mode := logic.GetResultMode(modeSelect.Selected)
+				start := logic.FramesToTimecode(entryFrames(timecode1Entry, format), format)
+				showCueList(window, format, start.Timecode, mode, pushHistory)
+			}),
+			fyne.NewMenuItemSeparator(),
+			fyne.NewMenuItem("Export History CSV…", func() {
+				showHistoryExport(window, historyList, "csv")
+			}),
+			fyne.NewMenuItem("Export History JSON…", func() {
+				showHistoryExport(window, historyList, "json")
+			}),
+		)
+		position := fyne.CurrentApp().Driver().AbsolutePositionForObject(toolsButton)
+		widget.ShowPopUpMenuAtPosition(menu, window.Canvas(), position.AddXY(0, toolsButton.Size().Height))
 	})
 
+	// Sample offset fields sit next to the frame counts, shown only in sample-accurate mode
+	samples1Wrap := container.NewGridWrap(fyne.NewSize(90, samples1Entry.MinSize().Height), samples1Entry)
+	samples2Wrap := container.NewGridWrap(fyne.NewSize(90, samples2Entry.MinSize().Height), samples2Entry)
+	samples1Wrap.Hide()
+	samples2Wrap.Hide()
+
+	// Footage rows sit below each timecode, shown only when a film format is selected
+	footage1Row := container.NewGridWithColumns(2, footage1Entry, footage1Label)
+	footage2Row := container.NewGridWithColumns(2, footage2Entry, footage2Label)
+	footage1Row.Hide()
+	footage2Row.Hide()
+
+	filmSelect.OnChanged = func(s string) {
+		if s == noFootage {
+			footage1Row.Hide()
+			footage2Row.Hide()
+		} else {
+			footage1Row.Show()
+			footage2Row.Show()
+		}
+		calculateTimecode1()
+		calculateTimecode2()
+	}
+
+	sampleRateSelect.OnChanged = func(s string) {
+		if s == framesOnly {
+			samples1Wrap.Hide()
+			samples2Wrap.Hide()
+		} else {
+			samples1Wrap.Show()
+			samples2Wrap.Show()
+		}
+		calculateTimecode1()
+		calculateTimecode2()
+	}
+
 	// Initialize
 	calculateTimecode1()
 	calculateTimecode2()
 
+	// Restore the history of the previous session
+	if saved := fyne.CurrentApp().Preferences().String(historyPreference); saved != "" {
+		if entries, err := logic.ReadHistoryJSON(strings.NewReader(saved)); err == nil {
+			historyList = entries
+			showHistory()
+		}
+	}
+
 	// Create the tab container
 	tabContainer := container.NewBorder(
 		container.NewVBox(
 			container.NewGridWithColumns(2,
 				timecode1Entry,
-				timecode1Label,
+				container.NewBorder(nil, nil, samples1Wrap, nil, timecode1Label),
 			),
+			footage1Row,
 			container.NewGridWithColumns(2,
 				timecode2Entry,
-				timecode2Label,
+				container.NewBorder(nil, nil, samples2Wrap, nil, timecode2Label),
 			),
+			footage2Row,
 			container.NewGridWithColumns(2,
 				addButton,
 				subtractButton,
 			),
+			container.NewBorder(nil, nil, nil, evaluateButton, expressionEntry),
 			widget.NewSeparator(),
 			container.NewGridWithColumns(3,
 				fpsSelect,
+				modeSelect,
+				filmSelect,
+			),
+			container.NewGridWithColumns(4,
+				sampleRateSelect,
+				toolsButton,
 				clearHistoryButton,
 				resetButton,
 			),
@@ -0,0 +1,130 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Cue list conversion modes
+const (
+	cueKeepTime   = "Keep running time"
+	cueKeepFrames = "Keep frames (speed change)"
+)
+
+// showCueList totals a list of durations with running start times from a start timecode.
+// The list can be converted to another frame rate; the totals can be passed to onReport.
+func showCueList(window fyne.Window, format logic.FPSFormat, start string, mode logic.ResultMode, onReport func(string)) {
+	fpsNames := []string{}
+	for _, f := range logic.FPSFormats {
+		fpsNames = append(fpsNames, f.Name)
+	}
+
+	listEntry := widget.NewMultiLineEntry()
+	listEntry.TextStyle.Monospace = true
+	listEntry.SetPlaceHolder("Paste durations, one per line, e.g.\n00:00:10:12  Opening\n00;01;00;02  Scene 2\n00.00.05.20")
+	listEntry.SetMinRowsVisible(8)
+
+	fpsSelect := widget.NewSelect(fpsNames, nil)
+	fpsSelect.SetSelected(format.Name)
+
+	startEntry := widget.NewEntry()
+	startEntry.SetText(start)
+
+	targetSelect := widget.NewSelect(fpsNames, nil)
+	targetSelect.SetSelected(format.Name)
+	convertModeSelect := widget.NewSelect([]string{cueKeepTime, cueKeepFrames}, nil)
+	convertModeSelect.SetSelected(cueKeepTime)
+
+	totalsText := widget.NewMultiLineEntry()
+	totalsText.TextStyle.Monospace = true
+	totalsText.Wrapping = fyne.TextWrapOff
+	totalsText.SetMinRowsVisible(8)
+
+	// Latest report, for the history
+	report := ""
+
+	update := func() {
+		listFormat := logic.GetFPSFormat(fpsSelect.Selected)
+		list, err := logic.ParseCueList(listEntry.Text, listFormat)
+		if err != nil {
+			report = ""
+			totalsText.SetText("⚠ " + err.Error())
+			return
+		}
+		startFrames, err := logic.ParseTimecode(startEntry.Text, listFormat)
+		if err != nil {
+			report = ""
+			totalsText.SetText("⚠ Start: " + err.Error())
+			return
+		}
+		report = formatCueListReport(list, startFrames, mode)
+		totalsText.SetText(report)
+	}
+	listEntry.OnChanged = func(string) { update() }
+	startEntry.OnChanged = func(string) { update() }
+	fpsSelect.OnChanged = func(string) { update() }
+
+	convertButton := widget.NewButton("Convert", func() {
+		list, err := logic.ParseCueList(listEntry.Text, logic.GetFPSFormat(fpsSelect.Selected))
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		target := logic.GetFPSFormat(targetSelect.Selected)
+		converted := list.Convert(target, convertModeSelect.Selected == cueKeepFrames)
+
+		// The start keeps its notation, like the tab's frame rate switch
+		listEntry.SetText(converted.String())
+		fpsSelect.SetSelected(target.Name)
+	})
+
+	historyButton := widget.NewButton("Add to History", func() {
+		if report != "" {
+			onReport(report)
+		}
+	})
+
+	content := container.NewBorder(
+		container.NewVBox(
+			container.NewGridWithColumns(2, widget.NewLabel("Frame Rate:"), fpsSelect),
+			container.NewGridWithColumns(2, widget.NewLabel("Start:"), startEntry),
+			listEntry,
+			container.NewGridWithColumns(2, targetSelect, convertButton),
+			convertModeSelect,
+			widget.NewSeparator(),
+		),
+		historyButton,
+		nil,
+		nil,
+		totalsText,
+	)
+
+	cueDialog := dialog.NewCustom("Cue List", "Close", content, window)
+	// Fill most of the parent window, the desktop window is only 450 wide
+	size := window.Canvas().Size()
+	cueDialog.Resize(fyne.NewSize(size.Width*0.95, size.Height*0.9))
+	update()
+	cueDialog.Show()
+}
+
+// formatCueListReport lists each cue's start, end, duration and running total
+func formatCueListReport(list *logic.CueList, start int, mode logic.ResultMode) string {
+	entries, total := list.Totals(start, mode)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Cue list @%s: %d cues, total %s (%df)\n", strings.Split(list.Format.Name, " ")[0], len(entries), total.Timecode, total.TotalFrames)
+	for i, entry := range entries {
+		fmt.Fprintf(&b, "%3d  %s - %s  %s  Σ %s", i+1, entry.Start.Timecode, entry.End.Timecode, entry.Duration.Timecode, entry.RunningTotal.Timecode)
+		if entry.Name != "" {
+			b.WriteString("  " + entry.Name)
+		}
+		b.WriteString("\n")
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// showEDLImport opens a CMX3600 EDL and passes a duration and gap report to onReport.
+// The EDL is read at the given frame rate; its FCM header picks drop or non-drop.
+func showEDLImport(window fyne.Window, format logic.FPSFormat, onReport func(string)) {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		edl, err := logic.ParseEDL(reader, format)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %v", reader.URI().Name(), err), window)
+			return
+		}
+		onReport(formatEDLReport(reader.URI().Name(), edl))
+	}, window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".edl", ".EDL"}))
+	openDialog.Show()
+}
+
+// formatEDLReport lists each event's source and record in/out and duration, followed by
+// the program totals and any gaps or overlaps on the record timeline
+func formatEDLReport(name string, edl *logic.EDL) string {
+	tc := func(frames int) string {
+		return logic.FramesToTimecode(frames, edl.Format).Timecode
+	}
+	fcm := "NDF"
+	if edl.DropFrame {
+		fcm = "DF"
+	}
+
+	var b strings.Builder
+	title := edl.Title
+	if title == "" {
+		title = name
+	}
+	fmt.Fprintf(&b, "EDL %s: %d events, %s @%s\n", title, len(edl.Events), fcm, strings.Split(edl.Format.Name, " ")[0])
+
+	for _, event := range edl.Events {
+		// Events after a later FCM header keep the format they were read at
+		eventTC := func(frames int) string {
+			return logic.FramesToTimecode(frames, event.Format).Timecode
+		}
+		fmt.Fprintf(&b, "%-3s %-4s src %s → %s  rec %s → %s  %s (%df)", event.Number, event.Track,
+			eventTC(event.SourceIn), eventTC(event.SourceOut), eventTC(event.RecordIn), eventTC(event.RecordOut),
+			eventTC(event.Duration), event.Duration)
+		if event.ClipName != "" {
+			fmt.Fprintf(&b, "  %s", event.ClipName)
+		}
+		b.WriteString("\n")
+	}
+
+	analysis := edl.Analyze()
+	fmt.Fprintf(&b, "Program %s → %s = %s (%df)\n",
+		tc(analysis.ProgramStart), tc(analysis.ProgramEnd), tc(analysis.ProgramDuration), analysis.ProgramDuration)
+	fmt.Fprintf(&b, "Events total = %s (%df)", tc(analysis.EventsDuration), analysis.EventsDuration)
+
+	for _, gap := range analysis.Gaps {
+		fmt.Fprintf(&b, "\n⚠ Gap %s %s–%s: %s → %s (%df)", gap.Track, gap.After, gap.Before,
+			tc(gap.Start), tc(gap.End), gap.End-gap.Start)
+	}
+	for _, overlap := range analysis.Overlaps {
+		fmt.Fprintf(&b, "\n⚠ Overlap %s %s–%s: %s → %s (%df)", overlap.Track, overlap.After, overlap.Before,
+			tc(overlap.Start), tc(overlap.End), overlap.End-overlap.Start)
+	}
+
+	return b.String()
+}
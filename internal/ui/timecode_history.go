@@ -0,0 +1,42 @@
+package ui
+
+import (
+	"errors"
+	"musicalc/internal/logic"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+)
+
+// showHistoryExport saves the timecode history as CSV or JSON ("csv" or "json"), oldest entry first
+func showHistoryExport(window fyne.Window, entries []logic.HistoryEntry, extension string) {
+	if len(entries) == 0 {
+		dialog.ShowError(errors.New("the history is empty"), window)
+		return
+	}
+
+	saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if writer == nil {
+			return // Cancelled
+		}
+		defer writer.Close()
+
+		if extension == "csv" {
+			err = logic.WriteHistoryCSV(writer, entries)
+		} else {
+			err = logic.WriteHistoryJSON(writer, entries)
+		}
+		if err != nil {
+			dialog.ShowError(err, window)
+		}
+	}, window)
+	saveDialog.SetFilter(storage.NewExtensionFileFilter([]string{"." + extension}))
+	saveDialog.SetFileName("timecode_history_" + time.Now().Format("2006-01-02") + "." + extension)
+	saveDialog.Show()
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"musicalc/internal/logic"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ltcSampleRates lists the sample rates offered for LTC files
+var ltcSampleRates = []string{"44100", "48000", "96000"}
+
+// showLTCGenerate asks for a start timecode and duration and renders LTC into a WAV file.
+// The start defaults to the given timecode; a summary is passed to onReport.
+func showLTCGenerate(window fyne.Window, format logic.FPSFormat, start string, onReport func(string)) {
+	if !logic.LTCSupported(format) {
+		dialog.ShowInformation("Generate LTC", "LTC is only defined for 24, 25 and 30 fps (including 23.976, 29.97 and 29.97 drop frame).", window)
+		return
+	}
+
+	timecodeValidator := func(s string) error {
+		_, err := logic.ParseTimecode(s, format)
+		return err
+	}
+
+	startEntry := widget.NewEntry()
+	startEntry.SetText(start)
+	startEntry.Validator = timecodeValidator
+
+	durationEntry := widget.NewEntry()
+	durationEntry.SetText(logic.FramesToTimecode(60*format.NominalRate(), format).Timecode)
+	durationEntry.Validator = func(s string) error {
+		frames, err := logic.ParseTimecode(s, format)
+		if err == nil && frames <= 0 {
+			err = fmt.Errorf("duration must be positive")
+		}
+		return err
+	}
+
+	sampleRateSelect := widget.NewSelect(ltcSampleRates, nil)
+	sampleRateSelect.SetSelected("48000")
+
+	userBitsEntry := widget.NewEntry()
+	userBitsEntry.SetPlaceHolder("00 00 00 00")
+	userBitsEntry.Validator = func(s string) error {
+		_, err := logic.ParseUserBits(s)
+		return err
+	}
+
+	levelEntry := widget.NewEntry()
+	levelEntry.SetText("-18")
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Start", startEntry),
+		widget.NewFormItem("Duration", durationEntry),
+		widget.NewFormItem("Sample Rate", sampleRateSelect),
+		widget.NewFormItem("User Bits", userBitsEntry),
+		widget.NewFormItem("Level (dBFS)", levelEntry),
+	}
+
+	form := dialog.NewForm("Generate LTC @"+strings.Split(format.Name, " ")[0], "Save…", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		startFrames, _ := logic.ParseTimecode(startEntry.Text, format)
+		durationFrames, _ := logic.ParseTimecode(durationEntry.Text, format)
+		userBits, _ := logic.ParseUserBits(userBitsEntry.Text)
+		opts := logic.LTCOptions{
+			Format:     format,
+			SampleRate: logic.ParseSampleRate(sampleRateSelect.Selected),
+			StartFrame: startFrames,
+			Frames:     durationFrames,
+			UserBits:   userBits,
+			LevelDBFS:  -math.Abs(logic.ParseFloat(levelEntry.Text)),
+		}
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+			defer writer.Close()
+
+			if err := logic.RenderLTC(writer, opts); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			onReport(formatLTCReport(writer.URI().Name(), opts))
+		}, window)
+		saveDialog.SetFileName("LTC_" + strings.NewReplacer(":", "", ";", "").Replace(logic.FramesToTimecode(startFrames, format).Timecode) + ".wav")
+		saveDialog.Show()
+	}, window)
+	form.Resize(fyne.NewSize(400, form.MinSize().Height))
+	form.Show()
+}
+
+// formatLTCReport summarizes a rendered LTC file for the history
+func formatLTCReport(name string, opts logic.LTCOptions) string {
+	start := logic.FramesToTimecode(opts.StartFrame, opts.Format)
+	end := logic.FramesToTimecode(logic.ApplyResultMode(opts.StartFrame+opts.Frames, opts.Format, logic.ResultWrap24h), opts.Format)
+	duration := logic.FramesToTimecode(opts.Frames, opts.Format)
+
+	return fmt.Sprintf("LTC %s\n  %s → %s (%s, %df) @%s\n  %d Hz, UB %s, %.1f dBFS",
+		name, start.Timecode, end.Timecode, duration.Timecode, opts.Frames, strings.Split(opts.Format.Name, " ")[0],
+		opts.SampleRate, logic.FormatUserBits(opts.UserBits), opts.LevelDBFS)
+}
+
+// maxLTCEvents limits how many dropouts and jumps are listed in the history
+const maxLTCEvents = 20
+
+// showLTCDecode reads the LTC from a WAV file and passes a report to onReport.
+// Long recordings take a while, so decoding runs in the background.
+func showLTCDecode(window fyne.Window, onReport func(string)) {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+
+		progress := dialog.NewCustomWithoutButtons("Reading LTC…", widget.NewProgressBarInfinite(), window)
+		progress.Show()
+
+		go func() {
+			defer reader.Close()
+			result, err := logic.DecodeLTC(reader)
+			fyne.Do(func() {
+				progress.Hide()
+				if err != nil {
+					dialog.ShowError(fmt.Errorf("%s: %v", reader.URI().Name(), err), window)
+					return
+				}
+				onReport(formatLTCDecodeReport(reader.URI().Name(), result))
+			})
+		}()
+	}, window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".wav", ".WAV"}))
+	openDialog.Show()
+}
+
+// formatLTCDecodeReport lists the first timecode, the detected rate and any dropouts or jumps
+func formatLTCDecodeReport(name string, result *logic.LTCDecodeResult) string {
+	seconds := func(sample int64) float64 {
+		return float64(sample) / float64(result.SampleRate)
+	}
+	flag := "NDF"
+	if result.First.DropFrame {
+		flag = "DF"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "LTC read %s (%d Hz)\n", name, result.SampleRate)
+	fmt.Fprintf(&b, "  first %s at sample %d (%.3f s)\n", result.FirstTC.Timecode, result.First.Sample, seconds(result.First.Sample))
+	fmt.Fprintf(&b, "  %s (measured %.3f fps), %s, UB %s\n", result.Format.Name, result.MeasuredFPS, flag, logic.FormatUserBits(result.First.UserBits))
+	fmt.Fprintf(&b, "  %d frames read", result.FrameCount)
+
+	for i, event := range result.Events {
+		if i == maxLTCEvents {
+			fmt.Fprintf(&b, "\n  … %d more", len(result.Events)-maxLTCEvents)
+			break
+		}
+		switch event.Kind {
+		case logic.LTCDropout:
+			fmt.Fprintf(&b, "\n⚠ Dropout at %d–%d (%.3f–%.3f s) after %s",
+				event.Sample, event.EndSample, seconds(event.Sample), seconds(event.EndSample), event.From.Timecode)
+		case logic.LTCDiscontinuity:
+			fmt.Fprintf(&b, "\n⚠ Jump at %d (%.3f s): %s → %s",
+				event.Sample, seconds(event.Sample), event.From.Timecode, event.To.Timecode)
+		}
+	}
+	if len(result.Events) == 0 {
+		b.WriteString("\n  no dropouts or jumps")
+	}
+
+	return b.String()
+}
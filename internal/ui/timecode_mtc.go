@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showMTC shows the MTC messages for a timecode and decodes pasted MTC bytes.
+// Decoded timecodes are passed to onReport.
+func showMTC(window fyne.Window, format logic.FPSFormat, totalFrames int, onReport func(string)) {
+	// Encoded messages for the current timecode, selectable for copying
+	encodedText := widget.NewMultiLineEntry()
+	encodedText.TextStyle.Monospace = true
+	encodedText.Wrapping = fyne.TextWrapWord
+
+	tc := logic.FramesToTimecode(logic.ApplyResultMode(totalFrames, format, logic.ResultWrap24h), format)
+	full, err := logic.EncodeMTCFullFrame(totalFrames, format, 0x7F)
+	if err != nil {
+		encodedText.SetText(err.Error())
+	} else {
+		quarters, _ := logic.EncodeMTCQuarterFrames(totalFrames, format)
+		var quarterBytes []string
+		for _, message := range quarters {
+			quarterBytes = append(quarterBytes, logic.FormatHexBytes(message[:]))
+		}
+		encodedText.SetText(fmt.Sprintf("Full frame:\n%s\nQuarter frames:\n%s",
+			logic.FormatHexBytes(full), strings.Join(quarterBytes, "  ")))
+	}
+
+	// Pasted bytes to decode
+	decodeEntry := widget.NewMultiLineEntry()
+	decodeEntry.TextStyle.Monospace = true
+	decodeEntry.Wrapping = fyne.TextWrapWord
+	decodeEntry.SetPlaceHolder("F0 7F 7F 01 01 20 00 00 00 F7")
+	decodeEntry.SetMinRowsVisible(4)
+
+	resultLabel := widget.NewLabel("")
+	resultLabel.Wrapping = fyne.TextWrapWord
+
+	decodeButton := widget.NewButton("Decode", func() {
+		data, err := logic.ParseHexBytes(decodeEntry.Text)
+		if err == nil {
+			var decoded []logic.MTCTimecode
+			decoded, err = logic.DecodeMTC(data, format)
+			if err == nil {
+				report := formatMTCReport(decoded)
+				resultLabel.SetText(report)
+				onReport("MTC " + logic.FormatHexBytes(data) + "\n" + report)
+				return
+			}
+		}
+		resultLabel.SetText("⚠ " + err.Error())
+	})
+
+	content := container.NewVBox(
+		widget.NewLabel(fmt.Sprintf("Timecode 1: %s @%s", tc.Timecode, strings.Split(format.Name, " ")[0])),
+		encodedText,
+		widget.NewSeparator(),
+		widget.NewLabel("Paste MTC bytes (hex):"),
+		decodeEntry,
+		decodeButton,
+		resultLabel,
+	)
+
+	mtcDialog := dialog.NewCustom("MIDI Time Code", "Close", container.NewVScroll(content), window)
+	mtcDialog.Resize(fyne.NewSize(420, 520))
+	mtcDialog.Show()
+}
+
+// formatMTCReport lists each decoded timecode with its rate code
+func formatMTCReport(decoded []logic.MTCTimecode) string {
+	var lines []string
+	for _, mtc := range decoded {
+		fpsLabel := strings.Split(mtc.Format.Name, " ")[0]
+		kind := "full frame"
+		if mtc.Kind == logic.MTCQuarterFrames {
+			kind = "quarter frames"
+			if mtc.Reverse {
+				kind = "reverse quarter frames"
+			}
+		}
+		if mtc.Invalid != "" {
+			lines = append(lines, fmt.Sprintf("@%d %s %s @%s, rate code %d\n   ⚠ invalid: %s",
+				mtc.Offset, kind, mtc.Timecode.Timecode, fpsLabel, mtc.RateCode, mtc.Invalid))
+			continue
+		}
+		line := fmt.Sprintf("@%d %s %s (%df) @%s, rate code %d",
+			mtc.Offset, kind, mtc.Timecode.Timecode, mtc.Timecode.TotalFrames, fpsLabel, mtc.RateCode)
+		if mtc.Kind == logic.MTCQuarterFrames {
+			line += "\n   position after 8 pieces " + mtc.Position.Timecode
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"musicalc/internal/logic"
+	"path/filepath"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/widget"
+)
+
+// showSubtitleRetime opens an SRT or WebVTT file, asks how to retime it and
+// saves the corrected copy. A summary is passed to onReport.
+func showSubtitleRetime(window fyne.Window, format logic.FPSFormat, onReport func(string)) {
+	openDialog := dialog.NewFileOpen(func(reader fyne.URIReadCloser, err error) {
+		if err != nil {
+			dialog.ShowError(err, window)
+			return
+		}
+		if reader == nil {
+			return // Cancelled
+		}
+		defer reader.Close()
+
+		file, err := logic.ParseSubtitles(reader)
+		if err != nil {
+			dialog.ShowError(fmt.Errorf("%s: %v", reader.URI().Name(), err), window)
+			return
+		}
+		showSubtitleRetimeForm(window, reader.URI().Name(), file, format, onReport)
+	}, window)
+	openDialog.SetFilter(storage.NewExtensionFileFilter([]string{".srt", ".vtt", ".SRT", ".VTT"}))
+	openDialog.Show()
+}
+
+func showSubtitleRetimeForm(window fyne.Window, name string, file *logic.SubtitleFile, format logic.FPSFormat, onReport func(string)) {
+	fpsFormats := []string{}
+	for _, f := range logic.FPSFormats {
+		fpsFormats = append(fpsFormats, f.Name)
+	}
+	sourceSelect := widget.NewSelect(fpsFormats, nil)
+	sourceSelect.SetSelected(format.Name)
+	targetSelect := widget.NewSelect(fpsFormats, nil)
+	targetSelect.SetSelected(format.Name)
+
+	rescaleCheck := widget.NewCheck("Keep cues on their frames", nil)
+	rescaleCheck.SetChecked(true)
+	snapCheck := widget.NewCheck("Snap to target frames", nil)
+	snapCheck.SetChecked(true)
+
+	// Offset is a signed timecode at the target rate
+	offsetEntry := widget.NewEntry()
+	offsetEntry.SetPlaceHolder("00:00:00:00")
+	offsetEntry.Validator = func(s string) error {
+		if strings.TrimSpace(s) == "" {
+			return nil
+		}
+		_, err := logic.ParseTimecode(s, logic.GetFPSFormat(targetSelect.Selected))
+		return err
+	}
+	targetSelect.OnChanged = func(string) { offsetEntry.Validate() }
+
+	outputSelect := widget.NewSelect(logic.SubtitleFormatNames, nil)
+	outputSelect.SetSelected(logic.SubtitleFormatNames[file.Format])
+
+	items := []*widget.FormItem{
+		widget.NewFormItem("Source Rate", sourceSelect),
+		widget.NewFormItem("Target Rate", targetSelect),
+		widget.NewFormItem("Rescale", rescaleCheck),
+		widget.NewFormItem("Offset", offsetEntry),
+		widget.NewFormItem("Frames", snapCheck),
+		widget.NewFormItem("Output", outputSelect),
+	}
+
+	form := dialog.NewForm(fmt.Sprintf("Retime %s (%d cues)", name, len(file.Cues)), "Save…", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+
+		target := logic.GetFPSFormat(targetSelect.Selected)
+		offsetFrames := 0
+		if strings.TrimSpace(offsetEntry.Text) != "" {
+			offsetFrames, _ = logic.ParseTimecode(offsetEntry.Text, target)
+		}
+		retime := logic.SubtitleRetime{
+			Source:       logic.GetFPSFormat(sourceSelect.Selected),
+			Target:       target,
+			Rescale:      rescaleCheck.Checked,
+			OffsetFrames: offsetFrames,
+			SnapToFrames: snapCheck.Checked,
+		}
+		output := logic.SubtitleSRT
+		if outputSelect.Selected == logic.SubtitleFormatNames[logic.SubtitleWebVTT] {
+			output = logic.SubtitleWebVTT
+		}
+		result := file.Retime(retime)
+
+		saveDialog := dialog.NewFileSave(func(writer fyne.URIWriteCloser, err error) {
+			if err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			if writer == nil {
+				return // Cancelled
+			}
+			defer writer.Close()
+
+			if err := logic.WriteSubtitles(writer, file, output); err != nil {
+				dialog.ShowError(err, window)
+				return
+			}
+			onReport(formatSubtitleReport(name, writer.URI().Name(), retime, result))
+		}, window)
+		saveDialog.SetFileName(strings.TrimSuffix(name, filepath.Ext(name)) + "_retimed" + output.Extension())
+		saveDialog.Show()
+	}, window)
+	form.Resize(fyne.NewSize(400, form.MinSize().Height))
+	form.Show()
+}
+
+// formatSubtitleReport summarizes a retime for the history
+func formatSubtitleReport(input, output string, retime logic.SubtitleRetime, result logic.SubtitleRetimeResult) string {
+	fpsLabel := func(format logic.FPSFormat) string {
+		return strings.Split(format.Name, " ")[0]
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Subtitles %s → %s: %d cues", input, output, result.Cues)
+	if retime.Rescale && retime.Source.Name != retime.Target.Name {
+		fmt.Fprintf(&b, "\n  rescaled @%s → @%s", fpsLabel(retime.Source), fpsLabel(retime.Target))
+	}
+	if retime.OffsetFrames != 0 {
+		fmt.Fprintf(&b, "\n  offset %s (%df) @%s", logic.FramesToTimecode(retime.OffsetFrames, retime.Target).Timecode,
+			retime.OffsetFrames, fpsLabel(retime.Target))
+	}
+	if retime.SnapToFrames {
+		fmt.Fprintf(&b, "\n  snapped to frames @%s", fpsLabel(retime.Target))
+	}
+	if result.Dropped > 0 {
+		fmt.Fprintf(&b, "\n⚠ %d cues dropped before 00:00:00:00", result.Dropped)
+	}
+	return b.String()
+}
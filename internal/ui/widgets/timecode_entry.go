@@ -16,6 +16,7 @@ type TimecodeEntry struct {
 	OnComplete       func()                 // Called when all digits are entered
 	OnOperationKey   func(key fyne.KeyName) // Called when +/- keys are pressed
 	fields           []string               // Field values [frames, seconds, minutes, hours] - right to left
+	negative         bool                   // Shows a leading minus sign (set from signed results only)
 	lastOpKey        fyne.KeyName
 	lastOpAt         time.Time
 }
@@ -30,14 +31,38 @@ func NewTimecodeEntry(threeDigits bool) *TimecodeEntry {
 	// Use monospace font for consistent character width
 	e.TextStyle.Monospace = true
 
-	// Set placeholder to show expected format
-	if threeDigits {
+	e.setPlaceHolder()
+
+	return e
+}
+
+// setPlaceHolder shows the expected format for the current frame digit count
+func (e *TimecodeEntry) setPlaceHolder() {
+	if e.ThreeDigitFrames {
 		e.PlaceHolder = "HH:MM:SS:FFF"
 	} else {
 		e.PlaceHolder = "HH:MM:SS:FF"
 	}
+}
 
-	return e
+// SetThreeDigitFrames switches between HH:MM:SS:FF and HH:MM:SS:FFF entry,
+// e.g. when a frame rate above 99 fps is selected
+func (e *TimecodeEntry) SetThreeDigitFrames(threeDigits bool) {
+	if e.ThreeDigitFrames == threeDigits {
+		return
+	}
+	e.ThreeDigitFrames = threeDigits
+	e.setPlaceHolder()
+
+	// Drop padding zeros that no longer fit the frames field
+	if !threeDigits && len(e.fields) > 0 {
+		for len(e.fields[0]) > 2 && e.fields[0][0] == '0' {
+			e.fields[0] = e.fields[0][1:]
+		}
+	}
+
+	e.updateDisplay()
+	e.Refresh()
 }
 
 func (e *TimecodeEntry) Keyboard() mobile.KeyboardType {
@@ -161,6 +186,7 @@ func (e *TimecodeEntry) updateDisplay() {
 	// Display as HH:MM:SS:FF
 
 	if len(e.fields) == 0 || (len(e.fields) == 1 && e.fields[0] == "") {
+		e.negative = false
 		e.Entry.SetText("")
 		e.CursorColumn = 0
 		return
@@ -209,6 +235,9 @@ func (e *TimecodeEntry) updateDisplay() {
 	}
 
 	formatted := fmt.Sprintf("%s:%s:%s:%s", hours, minutes, seconds, frames)
+	if e.negative {
+		formatted = "-" + formatted
+	}
 	e.Entry.SetText(formatted)
 	e.CursorColumn = len(formatted)
 }
@@ -252,10 +281,22 @@ func (e *TimecodeEntry) SetComponents(hours, minutes, seconds, frames int) {
 	e.updateDisplay()
 }
 
+// SetNegative marks the timecode as negative (e.g. a cue before program start)
+func (e *TimecodeEntry) SetNegative(negative bool) {
+	e.negative = negative
+	e.updateDisplay()
+}
+
+// IsNegative reports whether the timecode carries a leading minus sign
+func (e *TimecodeEntry) IsNegative() bool {
+	return e.negative
+}
+
 // SetText overrides to clear fields
 func (e *TimecodeEntry) SetText(text string) {
 	if text == "" {
 		e.fields = []string{""}
+		e.negative = false
 	}
 	e.Entry.SetText(text)
 }
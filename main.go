@@ -46,6 +46,11 @@ func main() {
 		"timecode":     "Timecode Calculator",
 		"tempo":        "Tempo to Delay",
 		"tempochange":  "Tempo Change",
+		"pullupdown":   "Pull-Up / Pull-Down",
+		"midiclock":    "MIDI Clock & PPQ",
+		"swing":        "Swing & Shuffle",
+		"bbt":          "Bars:Beats ↔ Timecode",
+		"hitpoints":    "Hit-Point Tempo Finder",
 		"note2freq":    "Note to Frequency",
 		"freq2note":    "Frequency to Note",
 		"samplelength": "Sample Length",
@@ -56,27 +61,50 @@ func main() {
 
 	// Determine tab text based on device type
 	isMobile := fyne.CurrentDevice().IsMobile()
-	var timecodeText, tempoText, tempoChangeText, note2freqText, freq2noteText, sampleLengthText, alignmentText string
+	var timecodeText, tempoText, tempoChangeText, pullUpDownText, midiClockText, swingText, bbtText, hitPointsText, note2freqText, freq2noteText, sampleLengthText, alignmentText string
 	if !isMobile {
 		timecodeText = "Timecode"
 		tempoText = "Delay"
 		tempoChangeText = "Tempo Chg"
+		pullUpDownText = "Pull Up/Dn"
+		midiClockText = "MIDI Clock"
+		swingText = "Swing"
+		bbtText = "Bars/Beats"
+		hitPointsText = "Hit Points"
 		note2freqText = "Note→Freq"
 		freq2noteText = "Freq→Note"
 		sampleLengthText = "Sample Len"
 		alignmentText = "Align Dly"
 	}
 
+	// Frame rate and start timecode shared by the tabs
+	session := ui.NewSession()
+
 	// Create all tab items
-	timecodeTab := container.NewTabItem(timecodeText, ui.NewTimecodeTab())
+	timecodeTab := container.NewTabItem(timecodeText, ui.NewTimecodeTab(session))
 	timecodeTab.Icon = ui.ResourceTimecodeSvg
 
-	tempoTab := container.NewTabItem(tempoText, ui.NewTempoTab())
+	tempoTab := container.NewTabItem(tempoText, ui.NewTempoTab(session))
 	tempoTab.Icon = ui.ResourceDelaySvg
 
-	tempoChangeTab := container.NewTabItem(tempoChangeText, ui.NewTempoChangeTab())
+	tempoChangeTab := container.NewTabItem(tempoChangeText, ui.NewTempoChangeTab(session))
 	tempoChangeTab.Icon = ui.ResourceTempochangeSvg
 
+	pullUpDownTab := container.NewTabItem(pullUpDownText, ui.NewPullUpDownTab())
+	pullUpDownTab.Icon = ui.ResourcePullupdownSvg
+
+	midiClockTab := container.NewTabItem(midiClockText, ui.NewMIDIClockTab())
+	midiClockTab.Icon = ui.ResourceMidiclockSvg
+
+	swingTab := container.NewTabItem(swingText, ui.NewSwingTab())
+	swingTab.Icon = ui.ResourceSwingSvg
+
+	bbtTab := container.NewTabItem(bbtText, ui.NewBBTTab(session))
+	bbtTab.Icon = ui.ResourceBbtSvg
+
+	hitPointsTab := container.NewTabItem(hitPointsText, ui.NewHitPointsTab())
+	hitPointsTab.Icon = ui.ResourceHitpointsSvg
+
 	note2freqTab := container.NewTabItem(note2freqText, ui.NewDiapasonTab())
 	note2freqTab.Icon = ui.ResourceNote2freqSvg
 
@@ -91,7 +119,8 @@ func main() {
 
 	// Create single AppTabs with ALL tabs (maintains left alignment)
 	allTabs := []*container.TabItem{
-		timecodeTab, tempoTab, tempoChangeTab,
+		timecodeTab, tempoTab, tempoChangeTab, pullUpDownTab, midiClockTab, swingTab,
+		bbtTab, hitPointsTab,
 		note2freqTab, freq2noteTab,
 		sampleLengthTab,
 		alignmentTab,
@@ -101,15 +130,17 @@ func main() {
 
 	// Define categories with their tab indices
 	categories := []CategoryInfo{
-		{Name: "Time & Tempo", TabIndices: []int{0, 1, 2}},
-		{Name: "Frequency & Pitch", TabIndices: []int{3, 4}},
-		{Name: "Analysis", TabIndices: []int{5}},
-		{Name: "Multi-Mic", TabIndices: []int{6}},
+		{Name: "Time & Tempo", TabIndices: []int{0, 1, 2, 3, 4, 5}},
+		{Name: "Scoring", TabIndices: []int{6, 7}},
+		{Name: "Frequency & Pitch", TabIndices: []int{8, 9}},
+		{Name: "Analysis", TabIndices: []int{10}},
+		{Name: "Multi-Mic", TabIndices: []int{11}},
 	}
 
 	// Tab heading keys for each global tab index
 	tabHeadingKeys := []string{
-		"timecode", "tempo", "tempochange",
+		"timecode", "tempo", "tempochange", "pullupdown", "midiclock", "swing",
+		"bbt", "hitpoints",
 		"note2freq", "freq2note",
 		"samplelength",
 		"alignment",